@@ -1,5 +1,7 @@
 package api
 
+import "iter"
+
 type GrowFlags int
 
 const (
@@ -10,18 +12,43 @@ const (
 	GROW_NO_OVERFLOW         GrowFlags = 4
 )
 
+// BoundType describes whether an edge of an interval includes its value, or if there is no edge at all.
+type BoundType int
+
+const (
+	BOUND_CLOSED    BoundType = 0
+	BOUND_OPEN      BoundType = 1
+	BOUND_UNBOUNDED BoundType = 2
+)
+
 type Interval[T Number] interface {
+	// String returns the interval in mathematical notation, like [1, 3] ∪ (5, ∞).
+	String() string
+
 	Min() T
 	Max() T
+	// Len returns the size of the interval, saturating at the maximum value of T.
+	// Use IsUnbounded to tell an infinite interval apart from a saturated one.
 	Len() T
 
+	MinBound() BoundType
+	MaxBound() BoundType
+
 	IsEmpty() bool
 	IsSingleton() bool
 	IsCompound() bool
+	IsUnbounded() bool
 
 	Enumerate(step T) []T
 	Intervals() []Interval[T]
 
+	// All iterates over the values of the interval in ascending order, step apart within each sub-interval.
+	All(step T) iter.Seq[T]
+	// Backward iterates over the values of the interval in descending order, step apart within each sub-interval.
+	Backward(step T) iter.Seq[T]
+	// SubIntervals iterates over the simple intervals making up the interval.
+	SubIntervals() iter.Seq[Interval[T]]
+
 	Contains(value T) bool
 	Overlaps(other Interval[T]) bool
 	Equals(other Interval[T]) bool
@@ -29,6 +56,7 @@ type Interval[T Number] interface {
 	Union(other Interval[T]) Interval[T]
 	Intersection(other Interval[T]) Interval[T]
 	Difference(other Interval[T]) Interval[T]
+	Complement() Interval[T]
 
 	Resize(newSize T, growMode GrowFlags) Interval[T]
 	Scale(scale float64, growMode GrowFlags) Interval[T]
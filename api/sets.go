@@ -1,7 +1,9 @@
 package api
 
-// BasicSet is an interface for a basic implementation of a set of comparables.
-type BasicSet[C comparable] interface {
+import "iter"
+
+// ReadOnlySet is the read-only part of a set of comparables, also implemented by immutable sets.
+type ReadOnlySet[C comparable] interface {
 	// String returns the string representation of the set.
 	// Example:
 	//  { (), (), () }
@@ -10,11 +12,44 @@ type BasicSet[C comparable] interface {
 	Len() int
 	// Elements returns the unique elements of the set.
 	Elements() []C
+	// Contains checked whether all elements are in the set.
+	Contains(elements ...C) bool
+}
+
+// BasicSet is an interface for a basic implementation of a set of comparables.
+type BasicSet[C comparable] interface {
+	ReadOnlySet[C]
+
 	// Add unique elements to the set.
 	// Repeated elements will be discarded.
 	Add(elements ...C)
-	// Contains checked whether all elements are in the set.
-	Contains(elements ...C) bool
 	// Remove any existing elements from the set.
 	Remove(elements ...C)
 }
+
+// Set is a [BasicSet] with iteration, copying and comparison with any other set of comparables.
+//
+// The algebra of sets, like union and intersection, is implemented per type,
+// and across implementations by the helpers of the sets package.
+type Set[C comparable] interface {
+	BasicSet[C]
+
+	// All iterates over the elements of the set.
+	// The set must not be changed while iterating.
+	All() iter.Seq[C]
+	// Clear removes all elements from the set.
+	Clear()
+	// Clone returns a copy of the set, of the same type.
+	Clone() Set[C]
+
+	// ContainsAny checks whether any of the elements is in the set.
+	ContainsAny(elements ...C) bool
+	// IsSubset checks whether all elements of the set are in the other set.
+	IsSubset(other ReadOnlySet[C]) bool
+	// IsSuperset checks whether all elements of the other set are in the set.
+	IsSuperset(other ReadOnlySet[C]) bool
+	// Equal checks whether both sets have the same elements.
+	Equal(other ReadOnlySet[C]) bool
+	// Disjoint checks whether the sets have no elements in common.
+	Disjoint(other ReadOnlySet[C]) bool
+}
@@ -40,7 +40,7 @@ import (
 	"github.com/toolvox/utilgo/pkg/errs"
 	"github.com/toolvox/utilgo/pkg/fsutil"
 	"github.com/toolvox/utilgo/pkg/logs"
-	"github.com/toolvox/utilgo/pkg/maputil"
+	"github.com/toolvox/utilgo/pkg/mathutil"
 	"github.com/toolvox/utilgo/pkg/reflectutil"
 )
 
@@ -111,7 +111,7 @@ func run(o countulaOpts, log *slog.Logger) error {
 	log.Debug("create matcher")
 	matcher := fsutil.NewGlobMatcher(includes, excludes)
 	skipPrefixes := o.IgnoreLinePrefix.Values
-	counts := map[string]map[string]int{}
+	var counts []fileCount
 
 	log.Debug("count lines")
 	matcher.WalkFS(fsys, func(path string, content []byte) error {
@@ -121,11 +121,6 @@ func run(o countulaOpts, log *slog.Logger) error {
 			ext = filepath.Base(path)
 		}
 		subDir := filepath.ToSlash(filepath.Dir(path))
-		subDirCounts, ok := counts[subDir]
-		if !ok {
-			subDirCounts = make(map[string]int)
-			counts[subDir] = subDirCounts
-		}
 
 		lines := strings.Split(string(content), "\n")
 		lines = slices.DeleteFunc(lines, reflectutil.IsZero)
@@ -142,7 +137,7 @@ func run(o countulaOpts, log *slog.Logger) error {
 			return false
 		})
 
-		subDirCounts[ext] += len(lines)
+		counts = append(counts, fileCount{Dir: subDir, Ext: ext, Lines: len(lines)})
 		return nil
 	})
 
@@ -152,67 +147,50 @@ func run(o countulaOpts, log *slog.Logger) error {
 	return nil
 }
 
-func renderCounts(counts map[string]map[string]int, dirMode bool, mergeMode bool) string {
+// fileCount is the line count of a single file.
+type fileCount struct {
+	Dir, Ext string
+	Lines    int
+}
+
+func renderCounts(counts []fileCount, dirMode bool, mergeMode bool) string {
 	var sb strings.Builder
-	total := 0
+	lines := func(fc fileCount) int { return fc.Lines }
+	byDir := func(fc fileCount) string { return fc.Dir }
+	byExt := func(fc fileCount) string { return fc.Ext }
+	sum := mathutil.GetAggregator[int](mathutil.AggSum)
 
 	if dirMode {
-		dirKeys := maputil.SortedKeys(counts)
-		for i, dir := range dirKeys {
-			extCounts := counts[dir]
+		total := mathutil.GroupBy(counts, lines, sum, byDir, byExt)
+		for i, dirGroup := range total.Groups {
 			if i > 0 {
 				sb.WriteRune('\n')
 			}
-			sb.WriteString(fmt.Sprintf("Directory `%s`:\n", dir))
-			exts := maputil.SortedKeys(extCounts)
-			dirCount := 0
-			for _, ext := range exts {
-				count := extCounts[ext]
-				total += count
-				dirCount += count
-				if !mergeMode {
-					sb.WriteString(fmt.Sprintf("    `%s`: %d\n", ext, count))
-				}
-			}
+			sb.WriteString(fmt.Sprintf("Directory `%s`:\n", dirGroup.Key))
 			if mergeMode {
-				sb.WriteString(fmt.Sprintf("    lines: %d\n", dirCount))
+				sb.WriteString(fmt.Sprintf("    lines: %d\n", dirGroup.Value))
+				continue
+			}
+			for _, extGroup := range dirGroup.Groups {
+				sb.WriteString(fmt.Sprintf("    `%s`: %d\n", extGroup.Key, extGroup.Value))
 			}
 		}
-		sb.WriteString(fmt.Sprintf("\n  ===  \nTotal lines: %d\n", total))
+		sb.WriteString(fmt.Sprintf("\n  ===  \nTotal lines: %d\n", total.Value))
 		return sb.String()
 	}
 
 	if mergeMode {
-		dirKeys := maputil.SortedKeys(counts)
-		for _, dir := range dirKeys {
-			extCounts := counts[dir]
-			for _, count := range extCounts {
-				total += count
-			}
-		}
-		sb.WriteString(fmt.Sprintf("Total lines: %d\n", total))
+		total := mathutil.GroupBy[fileCount, string](counts, lines, sum)
+		sb.WriteString(fmt.Sprintf("Total lines: %d\n", total.Value))
 		return sb.String()
 	}
 
-	extCounts := make(map[string]int)
-	dirKeys := maputil.SortedKeys(counts)
-	for _, dir := range dirKeys {
-		dirCounts := counts[dir]
-
-		exts := maputil.SortedKeys(dirCounts)
-		for _, ext := range exts {
-			count := dirCounts[ext]
-			extCounts[ext] += count
-			total += count
-		}
-	}
-
+	total := mathutil.GroupBy(counts, lines, sum, byExt)
 	sb.WriteString("Counts by extension:\n")
-	exts := maputil.SortedKeys(extCounts)
-	for _, ext := range exts {
-		sb.WriteString(fmt.Sprintf("    `%s`: %d\n", ext, extCounts[ext]))
+	for _, extGroup := range total.Groups {
+		sb.WriteString(fmt.Sprintf("    `%s`: %d\n", extGroup.Key, extGroup.Value))
 	}
 
-	sb.WriteString(fmt.Sprintf("\n  ===  \nTotal lines: %d\n", total))
+	sb.WriteString(fmt.Sprintf("\n  ===  \nTotal lines: %d\n", total.Value))
 	return sb.String()
 }
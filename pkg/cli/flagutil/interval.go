@@ -0,0 +1,129 @@
+package flagutil
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/toolvox/utilgo/api"
+	"github.com/toolvox/utilgo/pkg/errs"
+	"github.com/toolvox/utilgo/pkg/mathutil"
+)
+
+// IntervalValue holds an interval parsed from a list of ranges provided via flags.
+//
+// Ranges are comma-separated, and merged into a single interval:
+//
+//	10-20,40-50  ->  [10, 20] ∪ [40, 50]
+//	10-          ->  [10, ∞)
+//	-5           ->  (-∞, 5]
+//	7            ->  {7}
+//	-10--5       ->  [-10, -5]
+//	1e-5-2       ->  [1e-05, 2]
+//
+// A leading "-" opens the start of a range unless another "-" separates its edges, so a negative value
+// can only start a range with an end, or end one after a "--", like "--5" for (-∞, -5].
+// Values starting with a bracket are parsed as mathematical notation instead, see [mathutil.ParseInterval].
+// It is clearer for negative values, like "[-10, -5] ∪ [5, 10]".
+type IntervalValue[N api.Number] struct {
+	Interval api.Interval[N]
+}
+
+// String returns a string representation of the interval.
+// This method implements the [flag.Value] interface.
+func (iv IntervalValue[N]) String() string {
+	if iv.Interval == nil {
+		return ""
+	}
+	return iv.Interval.String()
+}
+
+// Set parses the input string as a list of ranges and stores the merged interval.
+// This method implements the [flag.Value] interface.
+func (iv *IntervalValue[N]) Set(value string) error {
+	value = strings.TrimSpace(value)
+	if strings.HasPrefix(value, "[") || strings.HasPrefix(value, "(") ||
+		strings.HasPrefix(value, "{") || strings.HasPrefix(value, "∅") {
+		interval, err := mathutil.ParseInterval[N](value)
+		if err != nil {
+			return err
+		}
+		iv.Interval = interval
+		return nil
+	}
+
+	var ranges []api.Interval[N]
+	for _, rangeText := range strings.Split(value, ",") {
+		interval, err := parseRange[N](strings.TrimSpace(rangeText))
+		if err != nil {
+			return fmt.Errorf("parsing '%s' to range: %w", rangeText, err)
+		}
+		ranges = append(ranges, interval)
+	}
+	iv.Interval = mathutil.NewMerged(ranges...)
+	return nil
+}
+
+// Get returns the interval as an interface{}.
+// This method implements the [flag.Getter] interface.
+// The return type is always [api.Interval][N].
+func (iv IntervalValue[N]) Get() any {
+	return iv.Interval
+}
+
+func parseRange[N api.Number](rangeText string) (api.Interval[N], error) {
+	sep := rangeSeparator(rangeText)
+	if sep < 0 && strings.HasPrefix(rangeText, "-") {
+		sep = 0
+	}
+	if sep < 0 {
+		value, err := mathutil.ParseNumber[N](rangeText)
+		if err != nil {
+			return nil, err
+		}
+		return mathutil.NewSingleton(value), nil
+	}
+
+	from, to := rangeText[:sep], rangeText[sep+1:]
+	switch {
+	case from == "" && to == "":
+		return nil, errs.New("range has no edges")
+
+	case from == "":
+		max, err := mathutil.ParseNumber[N](to)
+		if err != nil {
+			return nil, err
+		}
+		return mathutil.NewLeftUnbounded(max, true), nil
+
+	case to == "":
+		min, err := mathutil.ParseNumber[N](from)
+		if err != nil {
+			return nil, err
+		}
+		return mathutil.NewRightUnbounded(min, true), nil
+	}
+
+	min, err := mathutil.ParseNumber[N](from)
+	if err != nil {
+		return nil, err
+	}
+	max, err := mathutil.ParseNumber[N](to)
+	if err != nil {
+		return nil, err
+	}
+	if max < min {
+		return nil, errs.New("range ends before it starts")
+	}
+	return mathutil.NewClosed(min, max), nil
+}
+
+// rangeSeparator returns the index of the "-" between the edges of the range, or -1 if there is none.
+// A "-" at the start, or after another "-" or an exponent, is the sign of a value, like in "-10--5" or "1e-5-2".
+func rangeSeparator(rangeText string) int {
+	for i := 1; i < len(rangeText); i++ {
+		if rangeText[i] == '-' && !strings.ContainsRune("-eE", rune(rangeText[i-1])) {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,29 @@
+package flagutil_test
+
+import (
+	"flag"
+	"fmt"
+
+	"github.com/toolvox/utilgo/pkg/cli/flagutil"
+)
+
+// Typical use-case for [github.com/toolvox/utilgo/pkg/IntervalValue]
+func ExampleIntervalValue() {
+	// Setup:
+	var lines flagutil.IntervalValue[int]
+	fs := flag.NewFlagSet("cmd", flag.ContinueOnError)
+	fs.Var(&lines, "lines", "Line ranges")
+	fs.Parse([]string{"-lines", "10-20,40-50,90-"})
+
+	// Example:
+	fmt.Println("Lines:", lines.Get())
+	fmt.Println("Has 15:", lines.Interval.Contains(15))
+	fmt.Println("Has 30:", lines.Interval.Contains(30))
+	fmt.Println("Has 1000:", lines.Interval.Contains(1000))
+
+	// Output:
+	// Lines: [10, 20] ∪ [40, 50] ∪ [90, ∞)
+	// Has 15: true
+	// Has 30: false
+	// Has 1000: true
+}
@@ -0,0 +1,92 @@
+package flagutil_test
+
+import (
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/toolvox/utilgo/api"
+	"github.com/toolvox/utilgo/pkg/cli/cmds"
+	"github.com/toolvox/utilgo/pkg/cli/flagutil"
+	"github.com/toolvox/utilgo/pkg/mathutil"
+)
+
+func TestIntervalValue(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		wantErr  bool
+	}{
+		{name: "single range", input: "10-20", expected: "[10, 20]"},
+		{name: "range list", input: "10-20,40-50", expected: "[10, 20] ∪ [40, 50]"},
+		{name: "overlapping ranges", input: "10-20, 15-30, 31", expected: "[10, 31]"},
+		{name: "single value", input: "7", expected: "{7}"},
+		{name: "open end", input: "10-", expected: "[10, ∞)"},
+		{name: "open start", input: "-5", expected: "(-∞, 5]"},
+		{name: "open both", input: "-5,10-", expected: "(-∞, 5] ∪ [10, ∞)"},
+		{name: "negative start", input: "-3-5", expected: "[-3, 5]"},
+		{name: "negative range", input: "-10--5", expected: "[-10, -5]"},
+		{name: "negative open end", input: "-3-", expected: "[-3, ∞)"},
+		{name: "negative open start", input: "--5", expected: "(-∞, -5]"},
+		{name: "notation", input: "[-10, -5] ∪ (5, 10)", expected: "[-10, -5] ∪ [6, 9]"},
+		{name: "empty notation", input: "∅", expected: "∅"},
+		{name: "no edges", input: "-", wantErr: true},
+		{name: "reversed", input: "20-10", wantErr: true},
+		{name: "not a number", input: "a-b", wantErr: true},
+		{name: "empty range", input: "1-2,,3", wantErr: true},
+		{name: "bad notation", input: "[1, 2", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var iv flagutil.IntervalValue[int]
+			err := iv.Set(tt.input)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, iv.String())
+			require.Equal(t, tt.expected, iv.Get().(api.Interval[int]).String())
+		})
+	}
+
+	t.Run("Default", func(t *testing.T) {
+		var iv flagutil.IntervalValue[int]
+		require.Equal(t, "", iv.String())
+		require.Nil(t, iv.Get())
+	})
+
+	t.Run("FlagSet", func(t *testing.T) {
+		var iv flagutil.IntervalValue[int64]
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.Var(&iv, "lines", "line ranges")
+		require.NoError(t, fs.Parse([]string{"-lines", "10-20,40-50"}))
+		require.True(t, iv.Interval.Contains(45))
+		require.False(t, iv.Interval.Contains(30))
+	})
+
+	t.Run("CmdsFlagSet", func(t *testing.T) {
+		var iv flagutil.IntervalValue[float64]
+		var fs cmds.FlagSet
+		fs.Var(&iv, "window", "time window")
+		require.NoError(t, fs.Parse([]string{"cmd", "-window", "0.5-1.5"}))
+		require.True(t, iv.Interval.Equals(mathutil.NewClosed(0.5, 1.5)))
+	})
+
+	t.Run("Exponents", func(t *testing.T) {
+		for input, expected := range map[string]api.Interval[float64]{
+			"1e-5-2":      mathutil.NewClosed(1e-5, 2.0),
+			"-1E-3-1e-2":  mathutil.NewClosed(-1e-3, 1e-2),
+			"2.5e-1-":     mathutil.NewRightUnbounded(0.25, true),
+			"-1e-5":       mathutil.NewLeftUnbounded(1e-5, true),
+			"-2e-1--1e-1": mathutil.NewClosed(-0.2, -0.1),
+		} {
+			var iv flagutil.IntervalValue[float64]
+			require.NoError(t, iv.Set(input), input)
+			require.True(t, iv.Interval.Equals(expected), "%s: %s", input, iv.Interval)
+		}
+	})
+}
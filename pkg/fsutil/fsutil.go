@@ -3,22 +3,45 @@ package fsutil
 
 import (
 	"io/fs"
+	"iter"
 	"path/filepath"
 )
 
+// ListFS lists the paths of the files under root in the [fs.FS] matching the glob.
+// It stops at the first error, returning it with the paths found so far.
 func ListFS(fsys fs.FS, root, glob string) ([]string, error) {
 	var result []string
-	err := fs.WalkDir(fsys, root, func(path string, d fs.DirEntry, err error) error {
+	for path, err := range IterFS(fsys, root, glob) {
 		if err != nil {
-			return err
+			return result, err
 		}
-		if !d.IsDir() {
-			if ok, err := filepath.Match(glob, path); !ok || err != nil {
+		result = append(result, path)
+	}
+	return result, nil
+}
+
+// IterFS lazily walks the [fs.FS] from root, yielding the paths of the files matching the glob.
+// An error ends the walk, and is yielded last with an empty path.
+func IterFS(fsys fs.FS, root, glob string) iter.Seq2[string, error] {
+	return func(yield func(string, error) bool) {
+		err := fs.WalkDir(fsys, root, func(path string, d fs.DirEntry, err error) error {
+			if err != nil {
 				return err
 			}
-			result = append(result, path)
+			if d.IsDir() {
+				return nil
+			}
+			ok, err := filepath.Match(glob, path)
+			if err != nil {
+				return err
+			}
+			if ok && !yield(path, nil) {
+				return fs.SkipAll
+			}
+			return nil
+		})
+		if err != nil {
+			yield("", err)
 		}
-		return nil
-	})
-	return result, err
+	}
 }
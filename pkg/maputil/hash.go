@@ -0,0 +1,89 @@
+package maputil
+
+import (
+	"encoding/binary"
+	"hash/maphash"
+	"math"
+	"reflect"
+
+	"github.com/toolvox/utilgo/pkg/errs"
+)
+
+// Hasher can be implemented by keys of a [Persistent] map to provide their own hash.
+// Equal keys must have equal hashes.
+type Hasher interface {
+	Hash() uint64
+}
+
+// hashSeed seeds the hashes of [Persistent] keys, so all versions of all maps agree on them.
+var hashSeed = maphash.MakeSeed()
+
+// hashOf hashes any comparable key, so that equal keys have equal hashes.
+func hashOf[K comparable](key K) uint64 {
+	switch k := any(key).(type) {
+	case Hasher:
+		return k.Hash()
+	case string:
+		return maphash.String(hashSeed, k)
+	}
+
+	var h maphash.Hash
+	h.SetSeed(hashSeed)
+	writeHash(&h, reflect.ValueOf(&key).Elem())
+	return h.Sum64()
+}
+
+// writeHash writes the value to the hash by its kind, the same way for values that are ==.
+func writeHash(h *maphash.Hash, v reflect.Value) {
+	var buf [8]byte
+	writeUint := func(u uint64) {
+		binary.LittleEndian.PutUint64(buf[:], u)
+		h.Write(buf[:])
+	}
+	writeFloat := func(f float64) {
+		if f == 0 {
+			f = 0 // -0 == +0
+		}
+		writeUint(math.Float64bits(f))
+	}
+
+	switch v.Kind() {
+	case reflect.Bool:
+		if v.Bool() {
+			h.WriteByte(1)
+		} else {
+			h.WriteByte(0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		writeUint(uint64(v.Int()))
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		writeUint(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		writeFloat(v.Float())
+	case reflect.Complex64, reflect.Complex128:
+		writeFloat(real(v.Complex()))
+		writeFloat(imag(v.Complex()))
+	case reflect.String:
+		writeUint(uint64(v.Len()))
+		h.WriteString(v.String())
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		writeUint(uint64(v.Pointer()))
+	case reflect.Array:
+		for i := range v.Len() {
+			writeHash(h, v.Index(i))
+		}
+	case reflect.Struct:
+		for i := range v.NumField() {
+			writeHash(h, v.Field(i))
+		}
+	case reflect.Interface:
+		if v.IsNil() {
+			h.WriteByte(0)
+			return
+		}
+		h.WriteString(v.Elem().Type().String())
+		writeHash(h, v.Elem())
+	default:
+		panic(errs.Newf("hash of unhashable type %s", v.Type()))
+	}
+}
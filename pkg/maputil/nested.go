@@ -0,0 +1,283 @@
+package maputil
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/toolvox/utilgo/pkg/errs"
+)
+
+// PathSeparator separates the keys of nested maps in a path, like "server.tls.cert".
+// Keys containing it can not be reached by path.
+const PathSeparator = "."
+
+// ErrPathConflict is returned when a path goes through a value that is not a nested map.
+const ErrPathConflict = errs.Error("path conflict")
+
+// ErrMergeConflict is returned by [DeepMerge] when layers disagree on a value and conflicts are errors.
+const ErrMergeConflict = errs.Error("merge conflict")
+
+// SliceStrategy decides how [DeepMerge] combines two slices at the same path.
+type SliceStrategy int
+
+const (
+	// SliceReplace replaces the earlier slice with the later one.
+	SliceReplace SliceStrategy = iota
+	// SliceAppend appends the later slice to the earlier one.
+	SliceAppend
+	// SliceUnique appends the elements of the later slice which are not already in the earlier one.
+	SliceUnique
+)
+
+// ConflictStrategy decides how [DeepMerge] handles two different values at the same path which can not be merged.
+type ConflictStrategy int
+
+const (
+	// ConflictOverride keeps the later value.
+	ConflictOverride ConflictStrategy = iota
+	// ConflictKeep keeps the earlier value.
+	ConflictKeep
+	// ConflictError fails the merge with [ErrMergeConflict].
+	ConflictError
+)
+
+// MergeOptions configures [DeepMerge]. The zero value replaces slices and lets later values override earlier ones.
+type MergeOptions struct {
+	Slices    SliceStrategy
+	Conflicts ConflictStrategy
+}
+
+// DeepMerge merges the layers of nested maps into a new map, later layers on top of earlier ones.
+// Nested maps at the same path are merged recursively, slices and other values are combined according to the options.
+// Nested maps are copied, other values are shared with the layers.
+func DeepMerge(options MergeOptions, layers ...map[string]any) (map[string]any, error) {
+	result := map[string]any{}
+	for _, layer := range layers {
+		if err := options.merge(result, layer, ""); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// merge merges src into dst, which is owned by the result.
+func (o MergeOptions) merge(dst, src map[string]any, prefix string) error {
+	for _, key := range SortedKeys(src) {
+		existing, ok := dst[key]
+		if !ok {
+			dst[key] = cloneTree(src[key])
+			continue
+		}
+		merged, err := o.mergeValues(existing, src[key], joinPath(prefix, key))
+		if err != nil {
+			return err
+		}
+		dst[key] = merged
+	}
+	return nil
+}
+
+func (o MergeOptions) mergeValues(dst, src any, path string) (any, error) {
+	dstMap, dstIsMap := dst.(map[string]any)
+	srcMap, srcIsMap := src.(map[string]any)
+	if dstIsMap && srcIsMap {
+		return dstMap, o.merge(dstMap, srcMap, path)
+	}
+
+	dv, sv := reflect.ValueOf(dst), reflect.ValueOf(src)
+	if dv.Kind() == reflect.Slice && sv.Kind() == reflect.Slice {
+		if o.Slices == SliceReplace {
+			return src, nil
+		}
+		if dv.Type() == sv.Type() {
+			return mergeSlices(dv, sv, o.Slices == SliceUnique), nil
+		}
+	}
+
+	if reflect.DeepEqual(dst, src) {
+		return dst, nil
+	}
+	switch o.Conflicts {
+	case ConflictKeep:
+		return dst, nil
+	case ConflictError:
+		return nil, fmt.Errorf("%w: %s: %v != %v", ErrMergeConflict, path, dst, src)
+	}
+	return cloneTree(src), nil
+}
+
+// mergeSlices appends the elements of src to a copy of dst, skipping those already there if unique.
+func mergeSlices(dst, src reflect.Value, unique bool) any {
+	result := reflect.MakeSlice(dst.Type(), 0, dst.Len()+src.Len())
+	for _, slice := range []reflect.Value{dst, src} {
+		for i := range slice.Len() {
+			element := slice.Index(i)
+			if unique && containsValue(result, element) {
+				continue
+			}
+			result = reflect.Append(result, element)
+		}
+	}
+	return result.Interface()
+}
+
+func containsValue(slice, element reflect.Value) bool {
+	for i := range slice.Len() {
+		if reflect.DeepEqual(slice.Index(i).Interface(), element.Interface()) {
+			return true
+		}
+	}
+	return false
+}
+
+// cloneTree copies the nested maps of the value.
+func cloneTree(value any) any {
+	m, ok := value.(map[string]any)
+	if !ok {
+		return value
+	}
+	result := make(map[string]any, len(m))
+	for k, v := range m {
+		result[k] = cloneTree(v)
+	}
+	return result
+}
+
+func joinPath(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + PathSeparator + key
+}
+
+// Get returns the value at the dotted path in the nested maps, and whether it is there.
+func Get(m map[string]any, path string) (any, bool) {
+	keys := strings.Split(path, PathSeparator)
+	for _, key := range keys[:len(keys)-1] {
+		nested, ok := m[key].(map[string]any)
+		if !ok {
+			return nil, false
+		}
+		m = nested
+	}
+	value, ok := m[keys[len(keys)-1]]
+	return value, ok
+}
+
+// Set sets the value at the dotted path in the nested maps, creating missing maps along the way.
+// It returns an error wrapping [ErrPathConflict] if the path goes through a value that is not a map.
+func Set(m map[string]any, path string, value any) error {
+	keys := strings.Split(path, PathSeparator)
+	for i, key := range keys[:len(keys)-1] {
+		next, ok := m[key]
+		if !ok {
+			nested := map[string]any{}
+			m[key] = nested
+			m = nested
+			continue
+		}
+		nested, ok := next.(map[string]any)
+		if !ok {
+			return fmt.Errorf("%w: %s: %s is %T", ErrPathConflict, path, strings.Join(keys[:i+1], PathSeparator), next)
+		}
+		m = nested
+	}
+	m[keys[len(keys)-1]] = value
+	return nil
+}
+
+// Delete removes the value at the dotted path in the nested maps, and returns whether it was there.
+// The maps along the path are kept, even if left empty.
+func Delete(m map[string]any, path string) bool {
+	keys := strings.Split(path, PathSeparator)
+	for _, key := range keys[:len(keys)-1] {
+		nested, ok := m[key].(map[string]any)
+		if !ok {
+			return false
+		}
+		m = nested
+	}
+	last := keys[len(keys)-1]
+	_, ok := m[last]
+	delete(m, last)
+	return ok
+}
+
+// Flatten converts the nested maps into a single map from the dotted paths of their leaves to their values.
+// Empty nested maps are kept as leaves, so [Unflatten] restores them.
+func Flatten(m map[string]any) map[string]any {
+	result := map[string]any{}
+	flatten(result, m, "")
+	return result
+}
+
+func flatten(dst, m map[string]any, prefix string) {
+	for key, value := range m {
+		path := joinPath(prefix, key)
+		if nested, ok := value.(map[string]any); ok && len(nested) > 0 {
+			flatten(dst, nested, path)
+			continue
+		}
+		dst[path] = value
+	}
+}
+
+// Unflatten converts a map from dotted paths to values into nested maps, the inverse of [Flatten].
+// It returns an error wrapping [ErrPathConflict] if a path goes through the value of another, like "a" and "a.b".
+func Unflatten(flat map[string]any) (map[string]any, error) {
+	result := map[string]any{}
+	for _, path := range SortedKeys(flat) {
+		if err := Set(result, path, cloneTree(flat[path])); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// Delta is the structural difference between two nested maps, by the dotted paths of the values that differ.
+type Delta struct {
+	// Added holds the values at paths only in the new map.
+	Added map[string]any
+	// Removed holds the values at paths only in the old map.
+	Removed map[string]any
+	// Changed holds the old and new values at paths in both maps which are not equal and not both maps.
+	Changed map[string][2]any
+}
+
+// IsEmpty checks whether the maps of the [Delta] are equal.
+func (d Delta) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// Diff returns the structural difference from the old nested maps to the new ones, recursing into maps at the same path.
+// Other values are compared with [reflect.DeepEqual].
+func Diff(old, new map[string]any) Delta {
+	delta := Delta{Added: map[string]any{}, Removed: map[string]any{}, Changed: map[string][2]any{}}
+	delta.diff(old, new, "")
+	return delta
+}
+
+func (d Delta) diff(old, new map[string]any, prefix string) {
+	for key, oldValue := range old {
+		path := joinPath(prefix, key)
+		newValue, ok := new[key]
+		if !ok {
+			d.Removed[path] = oldValue
+			continue
+		}
+		oldMap, oldIsMap := oldValue.(map[string]any)
+		newMap, newIsMap := newValue.(map[string]any)
+		switch {
+		case oldIsMap && newIsMap:
+			d.diff(oldMap, newMap, path)
+		case !reflect.DeepEqual(oldValue, newValue):
+			d.Changed[path] = [2]any{oldValue, newValue}
+		}
+	}
+	for key, newValue := range new {
+		if _, ok := old[key]; !ok {
+			d.Added[joinPath(prefix, key)] = newValue
+		}
+	}
+}
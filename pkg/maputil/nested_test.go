@@ -0,0 +1,160 @@
+package maputil_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/toolvox/utilgo/pkg/maputil"
+)
+
+func newConfig() map[string]any {
+	return map[string]any{
+		"name": "app",
+		"server": map[string]any{
+			"port":  80,
+			"hosts": []any{"a", "b"},
+			"tls":   map[string]any{"cert": "a.pem"},
+		},
+	}
+}
+
+func TestDeepMerge(t *testing.T) {
+	override := map[string]any{
+		"server": map[string]any{
+			"port":  8080,
+			"hosts": []any{"b", "c"},
+			"tls":   map[string]any{"key": "a.key"},
+		},
+		"debug": true,
+	}
+
+	t.Run("Default", func(t *testing.T) {
+		must := require.New(t)
+		base := newConfig()
+		merged, err := maputil.DeepMerge(maputil.MergeOptions{}, base, override)
+		must.NoError(err)
+		must.Equal(map[string]any{
+			"name":  "app",
+			"debug": true,
+			"server": map[string]any{
+				"port":  8080,
+				"hosts": []any{"b", "c"},
+				"tls":   map[string]any{"cert": "a.pem", "key": "a.key"},
+			},
+		}, merged)
+		must.Equal(newConfig(), base, "layers are not modified")
+
+		must.NoError(maputil.Set(merged, "server.tls.cert", "b.pem"))
+		must.Equal(newConfig(), base, "nested maps are copied")
+	})
+
+	t.Run("Slices", func(t *testing.T) {
+		must := require.New(t)
+		merged, err := maputil.DeepMerge(maputil.MergeOptions{Slices: maputil.SliceAppend}, newConfig(), override)
+		must.NoError(err)
+		hosts, _ := maputil.Get(merged, "server.hosts")
+		must.Equal([]any{"a", "b", "b", "c"}, hosts)
+
+		merged, err = maputil.DeepMerge(maputil.MergeOptions{Slices: maputil.SliceUnique}, newConfig(), override)
+		must.NoError(err)
+		hosts, _ = maputil.Get(merged, "server.hosts")
+		must.Equal([]any{"a", "b", "c"}, hosts)
+
+		merged, err = maputil.DeepMerge(maputil.MergeOptions{Slices: maputil.SliceAppend},
+			map[string]any{"ports": []int{1}}, map[string]any{"ports": []int{2}})
+		must.NoError(err)
+		must.Equal([]int{1, 2}, merged["ports"])
+	})
+
+	t.Run("Conflicts", func(t *testing.T) {
+		must := require.New(t)
+		merged, err := maputil.DeepMerge(maputil.MergeOptions{Conflicts: maputil.ConflictKeep}, newConfig(), override)
+		must.NoError(err)
+		port, _ := maputil.Get(merged, "server.port")
+		must.Equal(80, port)
+		hosts, _ := maputil.Get(merged, "server.hosts")
+		must.Equal([]any{"b", "c"}, hosts)
+
+		_, err = maputil.DeepMerge(maputil.MergeOptions{Conflicts: maputil.ConflictError}, newConfig(), override)
+		must.ErrorIs(err, maputil.ErrMergeConflict)
+		must.ErrorContains(err, "server.port: 80 != 8080")
+
+		_, err = maputil.DeepMerge(maputil.MergeOptions{Conflicts: maputil.ConflictError}, newConfig(), newConfig())
+		must.NoError(err)
+
+		merged, err = maputil.DeepMerge(maputil.MergeOptions{}, newConfig(), map[string]any{"server": "off"})
+		must.NoError(err)
+		must.Equal("off", merged["server"])
+	})
+}
+
+func TestPaths(t *testing.T) {
+	must := require.New(t)
+	config := newConfig()
+
+	value, ok := maputil.Get(config, "server.tls.cert")
+	must.True(ok)
+	must.Equal("a.pem", value)
+	value, ok = maputil.Get(config, "name")
+	must.True(ok)
+	must.Equal("app", value)
+	_, ok = maputil.Get(config, "server.tls.key")
+	must.False(ok)
+	_, ok = maputil.Get(config, "name.first")
+	must.False(ok)
+
+	must.NoError(maputil.Set(config, "server.tls.key", "a.key"))
+	must.NoError(maputil.Set(config, "logs.level", "info"))
+	must.Equal(map[string]any{"level": "info"}, config["logs"])
+	err := maputil.Set(config, "name.first.letter", "a")
+	must.ErrorIs(err, maputil.ErrPathConflict)
+	must.ErrorContains(err, "name is string")
+
+	must.True(maputil.Delete(config, "server.tls.cert"))
+	must.False(maputil.Delete(config, "server.tls.cert"))
+	must.False(maputil.Delete(config, "name.first"))
+	must.Equal(map[string]any{"key": "a.key"}, config["server"].(map[string]any)["tls"])
+}
+
+func TestFlatten(t *testing.T) {
+	must := require.New(t)
+	config := newConfig()
+	config["empty"] = map[string]any{}
+
+	flat := maputil.Flatten(config)
+	must.Equal(map[string]any{
+		"name":            "app",
+		"empty":           map[string]any{},
+		"server.port":     80,
+		"server.hosts":    []any{"a", "b"},
+		"server.tls.cert": "a.pem",
+	}, flat)
+
+	nested, err := maputil.Unflatten(flat)
+	must.NoError(err)
+	must.Equal(config, nested)
+
+	_, err = maputil.Unflatten(map[string]any{"a": 1, "a.b": 2})
+	must.ErrorIs(err, maputil.ErrPathConflict)
+}
+
+func TestDiff(t *testing.T) {
+	must := require.New(t)
+	must.True(maputil.Diff(newConfig(), newConfig()).IsEmpty())
+
+	changed := newConfig()
+	must.NoError(maputil.Set(changed, "server.port", 8080))
+	must.NoError(maputil.Set(changed, "server.tls.key", "a.key"))
+	must.True(maputil.Delete(changed, "name"))
+	changed["server"].(map[string]any)["hosts"] = []any{"a"}
+
+	delta := maputil.Diff(newConfig(), changed)
+	must.False(delta.IsEmpty())
+	must.Equal(map[string]any{"server.tls.key": "a.key"}, delta.Added)
+	must.Equal(map[string]any{"name": "app"}, delta.Removed)
+	must.Equal(map[string][2]any{
+		"server.port":  {80, 8080},
+		"server.hosts": {[]any{"a", "b"}, []any{"a"}},
+	}, delta.Changed)
+}
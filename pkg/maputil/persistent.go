@@ -0,0 +1,348 @@
+package maputil
+
+import (
+	"encoding/json"
+	"fmt"
+	"iter"
+	"math/bits"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Bits of the hash consumed by every level of a [Persistent] map.
+const (
+	persistentBits  = 5
+	persistentWidth = 1 << persistentBits
+	persistentMask  = persistentWidth - 1
+)
+
+// Persistent is an immutable map of keys of type K to values of type V, based on a hash array mapped trie.
+//
+// Updates return a new version of the map, sharing most of its structure with the old one, in O(log n).
+// Versions never change, so they can be passed between goroutines and kept as snapshots without copying.
+//
+// The zero value is an empty map. Keys may implement [Hasher] to provide their own hash.
+type Persistent[K comparable, V any] struct {
+	root *persistentNode[K, V]
+	size int
+}
+
+// persistentNode is a node of the trie, with a slot for every bit set in its bitmap.
+// Below the last level, keys with the same full hash are kept in collisions instead.
+type persistentNode[K comparable, V any] struct {
+	bitmap     uint32
+	slots      []persistentSlot[K, V]
+	collisions []persistentLeaf[K, V]
+}
+
+// persistentSlot holds either a sub-node, or a leaf if node is nil.
+type persistentSlot[K comparable, V any] struct {
+	node *persistentNode[K, V]
+	leaf persistentLeaf[K, V]
+}
+
+type persistentLeaf[K comparable, V any] struct {
+	hash  uint64
+	key   K
+	value V
+}
+
+// NewPersistent initializes a new [Persistent] map with the entries of the given map.
+func NewPersistent[M ~map[K]V, K comparable, V any](entries M) Persistent[K, V] {
+	var result Persistent[K, V]
+	for k, v := range entries {
+		result = result.Set(k, v)
+	}
+	return result
+}
+
+// String returns the string representation of the [Persistent] map, like a go map with sorted keys.
+func (m Persistent[K, V]) String() string { return fmt.Sprint(m.Map()) }
+
+// Len counts the entries of the [Persistent] map.
+func (m Persistent[K, V]) Len() int { return m.size }
+
+// Get returns the value of the key, and whether the key is in the [Persistent] map.
+func (m Persistent[K, V]) Get(key K) (V, bool) {
+	if m.root != nil {
+		if leaf := m.root.get(hashOf(key), 0, key); leaf != nil {
+			return leaf.value, true
+		}
+	}
+	var zero V
+	return zero, false
+}
+
+// Has checks whether the key is in the [Persistent] map.
+func (m Persistent[K, V]) Has(key K) bool {
+	_, ok := m.Get(key)
+	return ok
+}
+
+// Set returns a new version of the [Persistent] map, with the key set to the value.
+func (m Persistent[K, V]) Set(key K, value V) Persistent[K, V] {
+	root := m.root
+	if root == nil {
+		root = &persistentNode[K, V]{}
+	}
+	root, added := root.set(persistentLeaf[K, V]{hash: hashOf(key), key: key, value: value}, 0)
+	if added {
+		return Persistent[K, V]{root: root, size: m.size + 1}
+	}
+	return Persistent[K, V]{root: root, size: m.size}
+}
+
+// Remove returns a new version of the [Persistent] map, without the keys.
+func (m Persistent[K, V]) Remove(keys ...K) Persistent[K, V] {
+	for _, key := range keys {
+		if m.root == nil {
+			break
+		}
+		root, removed := m.root.remove(hashOf(key), 0, key)
+		if !removed {
+			continue
+		}
+		if root.isEmpty() {
+			root = nil
+		}
+		m = Persistent[K, V]{root: root, size: m.size - 1}
+	}
+	return m
+}
+
+// All iterates over the entries of the [Persistent] map, in an unspecified but stable order.
+func (m Persistent[K, V]) All() iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		if m.root != nil {
+			m.root.all(yield)
+		}
+	}
+}
+
+// Keys iterates over the keys of the [Persistent] map, in the same order as [Persistent.All].
+func (m Persistent[K, V]) Keys() iter.Seq[K] {
+	return func(yield func(K) bool) {
+		for k := range m.All() {
+			if !yield(k) {
+				return
+			}
+		}
+	}
+}
+
+// Values iterates over the values of the [Persistent] map, in the same order as [Persistent.All].
+func (m Persistent[K, V]) Values() iter.Seq[V] {
+	return func(yield func(V) bool) {
+		for _, v := range m.All() {
+			if !yield(v) {
+				return
+			}
+		}
+	}
+}
+
+// Map returns the entries of the [Persistent] map as a new go map.
+func (m Persistent[K, V]) Map() map[K]V {
+	result := make(map[K]V, m.size)
+	for k, v := range m.All() {
+		result[k] = v
+	}
+	return result
+}
+
+// MarshalJSON converts the [Persistent] map to a JSON object.
+func (m Persistent[K, V]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(m.Map())
+}
+
+// UnmarshalJSON converts the JSON []byte to a [Persistent] map, replacing the version it points to.
+func (m *Persistent[K, V]) UnmarshalJSON(data []byte) error {
+	var entries map[K]V
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return err
+	}
+	*m = NewPersistent(entries)
+	return nil
+}
+
+// MarshalYAML converts the [Persistent] map to a YAML map.
+func (m Persistent[K, V]) MarshalYAML() (interface{}, error) {
+	return m.Map(), nil
+}
+
+// UnmarshalYAML converts the YAML [yaml.Node] (representing a YAML map) to a [Persistent] map, replacing the version it points to.
+func (m *Persistent[K, V]) UnmarshalYAML(value *yaml.Node) error {
+	var entries map[K]V
+	if err := value.Decode(&entries); err != nil {
+		return err
+	}
+	*m = NewPersistent(entries)
+	return nil
+}
+
+// slotOf returns the bit of the hash at the level of shift, and the position of its slot.
+func (n *persistentNode[K, V]) slotOf(hash uint64, shift uint) (uint32, int) {
+	bit := uint32(1) << ((hash >> shift) & persistentMask)
+	return bit, bits.OnesCount32(n.bitmap & (bit - 1))
+}
+
+func (n *persistentNode[K, V]) isEmpty() bool {
+	return n.bitmap == 0 && len(n.collisions) == 0
+}
+
+// singleLeaf returns the only leaf of the node, if it has no other entries.
+func (n *persistentNode[K, V]) singleLeaf() (persistentLeaf[K, V], bool) {
+	if len(n.collisions) == 1 {
+		return n.collisions[0], true
+	}
+	if len(n.slots) == 1 && n.slots[0].node == nil {
+		return n.slots[0].leaf, true
+	}
+	return persistentLeaf[K, V]{}, false
+}
+
+func (n *persistentNode[K, V]) get(hash uint64, shift uint, key K) *persistentLeaf[K, V] {
+	for shift < 64 {
+		bit, pos := n.slotOf(hash, shift)
+		if n.bitmap&bit == 0 {
+			return nil
+		}
+		slot := &n.slots[pos]
+		if slot.node == nil {
+			if slot.leaf.key == key {
+				return &slot.leaf
+			}
+			return nil
+		}
+		n, shift = slot.node, shift+persistentBits
+	}
+	for i := range n.collisions {
+		if n.collisions[i].key == key {
+			return &n.collisions[i]
+		}
+	}
+	return nil
+}
+
+// set returns a copy of the node with the leaf set, and whether its key was added.
+func (n *persistentNode[K, V]) set(leaf persistentLeaf[K, V], shift uint) (*persistentNode[K, V], bool) {
+	if shift >= 64 {
+		collisions := slices.Clone(n.collisions)
+		for i := range collisions {
+			if collisions[i].key == leaf.key {
+				collisions[i] = leaf
+				return &persistentNode[K, V]{collisions: collisions}, false
+			}
+		}
+		return &persistentNode[K, V]{collisions: append(collisions, leaf)}, true
+	}
+
+	bit, pos := n.slotOf(leaf.hash, shift)
+	if n.bitmap&bit == 0 {
+		return &persistentNode[K, V]{
+			bitmap: n.bitmap | bit,
+			slots:  slices.Insert(slices.Clone(n.slots), pos, persistentSlot[K, V]{leaf: leaf}),
+		}, true
+	}
+
+	slot := n.slots[pos]
+	var added bool
+	switch {
+	case slot.node != nil:
+		slot.node, added = slot.node.set(leaf, shift+persistentBits)
+	case slot.leaf.key == leaf.key:
+		slot.leaf = leaf
+	default:
+		slot = persistentSlot[K, V]{node: mergeLeaves(slot.leaf, leaf, shift+persistentBits)}
+		added = true
+	}
+	slots := slices.Clone(n.slots)
+	slots[pos] = slot
+	return &persistentNode[K, V]{bitmap: n.bitmap, slots: slots}, added
+}
+
+// mergeLeaves creates the node holding two leaves with different keys, whose hashes match up to shift.
+func mergeLeaves[K comparable, V any](a, b persistentLeaf[K, V], shift uint) *persistentNode[K, V] {
+	if shift >= 64 {
+		return &persistentNode[K, V]{collisions: []persistentLeaf[K, V]{a, b}}
+	}
+	ia, ib := (a.hash>>shift)&persistentMask, (b.hash>>shift)&persistentMask
+	if ia == ib {
+		return &persistentNode[K, V]{
+			bitmap: 1 << ia,
+			slots:  []persistentSlot[K, V]{{node: mergeLeaves(a, b, shift+persistentBits)}},
+		}
+	}
+	if ia > ib {
+		a, b, ia, ib = b, a, ib, ia
+	}
+	return &persistentNode[K, V]{
+		bitmap: 1<<ia | 1<<ib,
+		slots:  []persistentSlot[K, V]{{leaf: a}, {leaf: b}},
+	}
+}
+
+// remove returns a copy of the node without the key, and whether it was there.
+// Sub-nodes left with a single leaf are replaced by it, so equal maps have the same shape.
+func (n *persistentNode[K, V]) remove(hash uint64, shift uint, key K) (*persistentNode[K, V], bool) {
+	if shift >= 64 {
+		i := slices.IndexFunc(n.collisions, func(leaf persistentLeaf[K, V]) bool { return leaf.key == key })
+		if i < 0 {
+			return n, false
+		}
+		return &persistentNode[K, V]{collisions: slices.Delete(slices.Clone(n.collisions), i, i+1)}, true
+	}
+
+	bit, pos := n.slotOf(hash, shift)
+	if n.bitmap&bit == 0 {
+		return n, false
+	}
+	slot := n.slots[pos]
+	if slot.node == nil {
+		if slot.leaf.key != key {
+			return n, false
+		}
+		return &persistentNode[K, V]{
+			bitmap: n.bitmap &^ bit,
+			slots:  slices.Delete(slices.Clone(n.slots), pos, pos+1),
+		}, true
+	}
+
+	child, removed := slot.node.remove(hash, shift+persistentBits, key)
+	if !removed {
+		return n, false
+	}
+	if child.isEmpty() {
+		return &persistentNode[K, V]{
+			bitmap: n.bitmap &^ bit,
+			slots:  slices.Delete(slices.Clone(n.slots), pos, pos+1),
+		}, true
+	}
+	if leaf, ok := child.singleLeaf(); ok {
+		slot = persistentSlot[K, V]{leaf: leaf}
+	} else {
+		slot = persistentSlot[K, V]{node: child}
+	}
+	slots := slices.Clone(n.slots)
+	slots[pos] = slot
+	return &persistentNode[K, V]{bitmap: n.bitmap, slots: slots}, true
+}
+
+func (n *persistentNode[K, V]) all(yield func(K, V) bool) bool {
+	for _, slot := range n.slots {
+		if slot.node != nil {
+			if !slot.node.all(yield) {
+				return false
+			}
+		} else if !yield(slot.leaf.key, slot.leaf.value) {
+			return false
+		}
+	}
+	for _, leaf := range n.collisions {
+		if !yield(leaf.key, leaf.value) {
+			return false
+		}
+	}
+	return true
+}
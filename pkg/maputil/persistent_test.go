@@ -0,0 +1,200 @@
+package maputil_test
+
+import (
+	"encoding/json"
+	"maps"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/toolvox/utilgo/pkg/maputil"
+)
+
+// collidingKey hashes all keys the same, to exercise full hash collisions.
+type collidingKey int
+
+func (collidingKey) Hash() uint64 { return 42 }
+
+// prefixKey hashes keys with the same low bits, to exercise deep tries.
+type prefixKey int
+
+func (k prefixKey) Hash() uint64 { return uint64(k) << 50 }
+
+func Test_Persistent(t *testing.T) {
+	t.Run("Versions", func(t *testing.T) {
+		must := require.New(t)
+		var empty maputil.Persistent[string, int]
+		must.Equal(0, empty.Len())
+		_, ok := empty.Get("a")
+		must.False(ok)
+
+		v1 := empty.Set("a", 1).Set("b", 2)
+		v2 := v1.Set("a", 10).Set("c", 3)
+		v3 := v2.Remove("b", "missing")
+
+		must.Equal(0, empty.Len())
+		must.Equal(map[string]int{"a": 1, "b": 2}, v1.Map())
+		must.Equal(map[string]int{"a": 10, "b": 2, "c": 3}, v2.Map())
+		must.Equal(map[string]int{"a": 10, "c": 3}, v3.Map())
+		must.Equal(2, v1.Len())
+		must.Equal(3, v2.Len())
+		must.Equal(2, v3.Len())
+
+		value, ok := v2.Get("a")
+		must.True(ok)
+		must.Equal(10, value)
+		must.True(v3.Has("c"))
+		must.False(v3.Has("b"))
+		must.Equal(0, v3.Remove("a", "c").Len())
+		must.Equal("map[a:10 c:3]", v3.String())
+	})
+
+	t.Run("Random", func(t *testing.T) {
+		must := require.New(t)
+		rng := rand.New(rand.NewPCG(1, 2))
+		expected := map[int]int{}
+		var actual maputil.Persistent[int, int]
+
+		type snapshot struct {
+			version maputil.Persistent[int, int]
+			entries map[int]int
+		}
+		var snapshots []snapshot
+		for i := range 20_000 {
+			key := rng.IntN(2_000)
+			if rng.IntN(3) == 0 {
+				delete(expected, key)
+				actual = actual.Remove(key)
+			} else {
+				expected[key] = i
+				actual = actual.Set(key, i)
+			}
+			must.Equal(len(expected), actual.Len())
+			if i%2_000 == 0 {
+				snapshots = append(snapshots, snapshot{actual, maps.Clone(expected)})
+			}
+		}
+		must.Equal(expected, actual.Map())
+		for key, value := range expected {
+			got, ok := actual.Get(key)
+			must.True(ok)
+			must.Equal(value, got)
+		}
+		for _, snap := range snapshots {
+			must.Equal(snap.entries, snap.version.Map())
+		}
+	})
+
+	t.Run("Collisions", func(t *testing.T) {
+		for name, test := range map[string]func(t *testing.T){
+			"full":   func(t *testing.T) { testPersistentKeys(t, func(i int) collidingKey { return collidingKey(i) }) },
+			"prefix": func(t *testing.T) { testPersistentKeys(t, func(i int) prefixKey { return prefixKey(i) }) },
+		} {
+			t.Run(name, test)
+		}
+	})
+
+	t.Run("Keys", func(t *testing.T) {
+		must := require.New(t)
+		type point struct {
+			X, Y int
+			Name string
+		}
+		points := maputil.Persistent[point, bool]{}.Set(point{1, 2, "a"}, true).Set(point{1, 2, "b"}, false)
+		must.Equal(2, points.Len())
+		value, ok := points.Get(point{1, 2, "a"})
+		must.True(ok)
+		must.True(value)
+
+		floats := maputil.Persistent[float64, string]{}.Set(math.Copysign(0, -1), "zero")
+		value2, ok := floats.Get(0)
+		must.True(ok)
+		must.Equal("zero", value2)
+
+		anys := maputil.Persistent[any, int]{}.Set(1, 1).Set("1", 2).Set([2]int{1, 1}, 3).Set(nil, 4)
+		must.Equal(4, anys.Len())
+		for key, want := range map[any]int{1: 1, "1": 2, [2]int{1, 1}: 3, nil: 4} {
+			got, ok := anys.Get(key)
+			must.True(ok, "%v", key)
+			must.Equal(want, got)
+		}
+		must.False(anys.Has(int64(1)))
+
+		a, b := new(int), new(int)
+		pointers := maputil.Persistent[*int, int]{}.Set(a, 1).Set(b, 2)
+		got, _ := pointers.Get(a)
+		must.Equal(1, got)
+
+		must.Panics(func() { maputil.Persistent[any, int]{}.Set([]int{1}, 1) })
+	})
+
+	t.Run("Iterate", func(t *testing.T) {
+		must := require.New(t)
+		m := maputil.NewPersistent(map[string]int{"a": 1, "b": 2, "c": 3})
+		must.ElementsMatch([]string{"a", "b", "c"}, slices.Collect(m.Keys()))
+		must.ElementsMatch([]int{1, 2, 3}, slices.Collect(m.Values()))
+		var count int
+		for range m.All() {
+			count++
+			break
+		}
+		must.Equal(1, count)
+		must.Equal(slices.Collect(m.Keys()), slices.Collect(m.Keys()))
+	})
+}
+
+func testPersistentKeys[K interface {
+	comparable
+	~int
+}](t *testing.T, key func(int) K) {
+	must := require.New(t)
+	var m maputil.Persistent[K, int]
+	for i := range 100 {
+		m = m.Set(key(i), i)
+	}
+	must.Equal(100, m.Len())
+	for i := range 100 {
+		value, ok := m.Get(key(i))
+		must.True(ok)
+		must.Equal(i, value)
+	}
+	_, ok := m.Get(key(100))
+	must.False(ok)
+
+	half := m
+	for i := 0; i < 100; i += 2 {
+		half = half.Remove(key(i))
+	}
+	must.Equal(50, half.Len())
+	must.Equal(100, m.Len())
+	for i := range 100 {
+		must.Equal(i%2 == 1, half.Has(key(i)))
+	}
+	for i := 1; i < 100; i += 2 {
+		half = half.Remove(key(i))
+	}
+	must.Equal(0, half.Len())
+}
+
+func Test_Persistent_Encoding(t *testing.T) {
+	must := require.New(t)
+	m := maputil.NewPersistent(map[string]int{"b": 2, "a": 1})
+
+	bs, err := json.Marshal(m)
+	must.NoError(err)
+	must.Equal(`{"a":1,"b":2}`, string(bs))
+	var jsonMap maputil.Persistent[string, int]
+	must.NoError(json.Unmarshal(bs, &jsonMap))
+	must.Equal(m.Map(), jsonMap.Map())
+
+	bs, err = yaml.Marshal(m)
+	must.NoError(err)
+	must.Equal("a: 1\nb: 2\n", string(bs))
+	var yamlMap maputil.Persistent[string, int]
+	must.NoError(yaml.Unmarshal(bs, &yamlMap))
+	must.Equal(m.Map(), yamlMap.Map())
+}
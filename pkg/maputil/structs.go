@@ -1,28 +1,15 @@
 package maputil
 
-import "encoding/json"
+import "github.com/toolvox/utilgo/pkg/reflectutil"
 
-// MapToStruct converts a map to a struct by converting through json.
+// MapToStruct converts a map to a struct, using [reflectutil.FromMap] with its default options.
 func MapToStruct[T any](m map[string]any) (T, error) {
 	var result T
-	data, err := json.Marshal(m)
-	if err != nil {
-		return result, err
-	}
-	err = json.Unmarshal(data, &result)
+	err := reflectutil.FromMap(m, &result, reflectutil.ConvertOptions{})
 	return result, err
 }
 
-// StructToMap converts a struct to a map by converting through json.
+// StructToMap converts a struct to a map, using [reflectutil.ToMap] with its default options.
 func StructToMap[T any](t T) (map[string]any, error) {
-	data, err := json.Marshal(t)
-	if err != nil {
-		return nil, err
-	}
-	var out map[string]any
-	err = json.Unmarshal(data, &out)
-	if err != nil {
-		return nil, err
-	}
-	return out, nil
+	return reflectutil.ToMap(t, reflectutil.ConvertOptions{})
 }
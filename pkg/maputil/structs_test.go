@@ -0,0 +1,46 @@
+package maputil_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/toolvox/utilgo/pkg/maputil"
+	"github.com/toolvox/utilgo/pkg/reflectutil"
+)
+
+type structsPerson struct {
+	Name string
+	Age  int
+}
+
+func TestMapToStruct(t *testing.T) {
+	must := require.New(t)
+	person, err := maputil.MapToStruct[structsPerson](map[string]any{"name": "x", "age": 3})
+	must.NoError(err)
+	must.Equal(structsPerson{Name: "x", Age: 3}, person)
+
+	person, err = maputil.MapToStruct[structsPerson](map[string]any{"Name": "y", "AGE": 4.0, "Other": true})
+	must.NoError(err)
+	must.Equal(structsPerson{Name: "y", Age: 4}, person)
+
+	_, err = maputil.MapToStruct[structsPerson](map[string]any{"age": "old"})
+	must.ErrorIs(err, reflectutil.ErrConvert)
+}
+
+type structsNode struct {
+	Name   string
+	Parent *structsNode
+}
+
+func TestStructToMap(t *testing.T) {
+	must := require.New(t)
+	m, err := maputil.StructToMap(structsNode{Name: "leaf", Parent: &structsNode{Name: "root"}})
+	must.NoError(err)
+	must.Equal(map[string]any{"Name": "leaf", "Parent": map[string]any{"Name": "root", "Parent": nil}}, m)
+
+	node := &structsNode{Name: "self"}
+	node.Parent = node
+	_, err = maputil.StructToMap(node)
+	must.ErrorIs(err, reflectutil.ErrConvert)
+}
@@ -0,0 +1,675 @@
+package mathutil
+
+import (
+	"cmp"
+	"math"
+	"math/big"
+	"slices"
+
+	"github.com/toolvox/utilgo/pkg/errs"
+)
+
+// Accumulator aggregates values one at a time, without keeping them around.
+//
+// The zero value of every accumulator in this package is ready to use, unless it has a constructor.
+type Accumulator[N Number] interface {
+	// Add adds values to the accumulator.
+	Add(values ...N)
+	// Result returns the aggregate of all the values added so far.
+	Result() N
+	// Merge adds the state of other, an accumulator of the same type, to the accumulator.
+	Merge(other Accumulator[N]) error
+}
+
+// Accumulated creates an [AggregatorFunc] that adds the values to a new accumulator and returns its result.
+func Accumulated[N Number](newAccumulator func() Accumulator[N]) AggregatorFunc[N] {
+	return func(values ...N) N {
+		acc := newAccumulator()
+		acc.Add(values...)
+		return acc.Result()
+	}
+}
+
+// GetAccumulator returns a new [Accumulator] corresponding to the given AggregatorKey.
+//
+// Keys without an online form, like [AggXenoSum], keep the values and aggregate them on demand.
+func GetAccumulator[N Number](key AggregatorKey) Accumulator[N] {
+	if acc, ok := newAccumulator[N](key); ok {
+		return acc
+	}
+	return &bufferedAccumulator[N]{key: key, aggregate: GetAggregator[N](key)}
+}
+
+func newAccumulator[N Number](key AggregatorKey) (Accumulator[N], bool) {
+	switch key {
+	case AggCount:
+		return &CountAccumulator[N]{}, true
+	case AggMax:
+		return &MaxAccumulator[N]{}, true
+	case AggMin:
+		return &MinAccumulator[N]{}, true
+	case AggSum:
+		return &SumAccumulator[N]{}, true
+	case AggAverage:
+		return &MeanAccumulator[N]{}, true
+	case AggProduct:
+		return &ProductAccumulator[N]{}, true
+	case AggGeometricMean:
+		return &GeometricMeanAccumulator[N]{}, true
+	case AggHarmonicMean:
+		return &HarmonicMeanAccumulator[N]{}, true
+	case AggVariance:
+		return &VarianceAccumulator[N]{}, true
+	case AggStdDev:
+		return &StdDevAccumulator[N]{}, true
+	case AggMedian:
+		return NewQuantileAccumulator[N](0.5), true
+	case AggP90:
+		return NewQuantileAccumulator[N](0.9), true
+	case AggP99:
+		return NewQuantileAccumulator[N](0.99), true
+	case AggRange:
+		return &RangeAccumulator[N]{}, true
+	default:
+		return nil, false
+	}
+}
+
+// CountAccumulator counts the values, saturating at the max value of N.
+type CountAccumulator[N Number] struct {
+	count int
+}
+
+// Add adds values to the accumulator.
+func (ca *CountAccumulator[N]) Add(values ...N) { ca.count += len(values) }
+
+// Result returns the number of values added so far.
+func (ca *CountAccumulator[N]) Result() N { return countOf[N](ca.count) }
+
+// Merge adds the count of other to the accumulator.
+func (ca *CountAccumulator[N]) Merge(other Accumulator[N]) error {
+	o, err := mergeable(ca, other)
+	if err != nil {
+		return err
+	}
+	ca.count += o.count
+	return nil
+}
+
+// SumAccumulator sums the values.
+// Like [Sum], integer sums saturate at the bounds of N.
+type SumAccumulator[N Number] struct {
+	sum N
+	// exact holds the sum once it overflows N
+	exact *big.Int
+}
+
+// Add adds values to the accumulator.
+func (sa *SumAccumulator[N]) Add(values ...N) {
+	for i, v := range values {
+		if sa.exact != nil {
+			bigSum(values[i:], sa.exact)
+			return
+		}
+		next, ok := addChecked(sa.sum, v)
+		if !ok {
+			sa.exact = bigSum(values[i:], bigOf(sa.sum))
+			return
+		}
+		sa.sum = next
+	}
+}
+
+// Result returns the sum of the values added so far.
+func (sa *SumAccumulator[N]) Result() N {
+	if sa.exact != nil {
+		return fromBig[N](sa.exact)
+	}
+	return sa.sum
+}
+
+// Merge adds the sum of other to the accumulator.
+func (sa *SumAccumulator[N]) Merge(other Accumulator[N]) error {
+	o, err := mergeable(sa, other)
+	if err != nil {
+		return err
+	}
+	switch {
+	case o.exact == nil:
+		sa.Add(o.sum)
+	case sa.exact == nil:
+		sa.exact = new(big.Int).Add(bigOf(sa.sum), o.exact)
+	default:
+		sa.exact.Add(sa.exact, o.exact)
+	}
+	return nil
+}
+
+// ProductAccumulator multiplies the values.
+// Like [Product], it returns 0 when there are no values, and integer products saturate at the bounds of N.
+type ProductAccumulator[N Number] struct {
+	prod  N
+	count int
+	// saturated is the sign of the product once it overflows N, after which only a zero can change its magnitude
+	saturated int
+}
+
+// Add adds values to the accumulator.
+func (pa *ProductAccumulator[N]) Add(values ...N) {
+	for _, v := range values {
+		pa.count++
+		switch {
+		case pa.count == 1:
+			pa.prod = v
+		case pa.saturated != 0:
+			pa.multiplySaturated(signOf(v))
+		default:
+			next, ok := mulChecked(pa.prod, v)
+			if !ok {
+				pa.saturated = signOf(pa.prod) * signOf(v)
+			}
+			pa.prod = next
+		}
+	}
+}
+
+// multiplySaturated multiplies the saturated product by a value of the sign.
+func (pa *ProductAccumulator[N]) multiplySaturated(sign int) {
+	if sign == 0 {
+		pa.prod, pa.saturated = 0, 0
+		return
+	}
+	pa.saturated *= sign
+}
+
+// Result returns the product of the values added so far.
+func (pa *ProductAccumulator[N]) Result() N {
+	switch {
+	case pa.saturated > 0:
+		return MaxValue[N]()
+	case pa.saturated < 0:
+		return MinValue[N]()
+	}
+	return pa.prod
+}
+
+// Merge multiplies the product of other into the accumulator.
+func (pa *ProductAccumulator[N]) Merge(other Accumulator[N]) error {
+	o, err := mergeable(pa, other)
+	if err != nil {
+		return err
+	}
+	switch {
+	case o.count == 0:
+	case pa.count == 0:
+		*pa = *o
+	case o.saturated == 0:
+		pa.Add(o.prod)
+		pa.count += o.count - 1
+	default:
+		if pa.saturated == 0 {
+			pa.saturated = signOf(pa.prod)
+			if pa.saturated == 0 {
+				pa.count += o.count
+				return nil
+			}
+		}
+		pa.saturated *= o.saturated
+		pa.count += o.count
+	}
+	return nil
+}
+
+// signOf returns -1, 0 or 1 for negative, zero and positive v.
+func signOf[N Number](v N) int {
+	switch {
+	case v < 0:
+		return -1
+	case v > 0:
+		return 1
+	}
+	return 0
+}
+
+// MinAccumulator finds the minimum value.
+// Like [Min], it returns 0 when there are no values.
+type MinAccumulator[N Number] struct {
+	min  N
+	seen bool
+}
+
+// Add adds values to the accumulator.
+func (ma *MinAccumulator[N]) Add(values ...N) {
+	for _, v := range values {
+		if !ma.seen || v < ma.min {
+			ma.min, ma.seen = v, true
+		}
+	}
+}
+
+// Result returns the minimum of the values added so far.
+func (ma *MinAccumulator[N]) Result() N { return ma.min }
+
+// Merge adds the minimum of other to the accumulator.
+func (ma *MinAccumulator[N]) Merge(other Accumulator[N]) error {
+	o, err := mergeable(ma, other)
+	if err != nil {
+		return err
+	}
+	if o.seen {
+		ma.Add(o.min)
+	}
+	return nil
+}
+
+// MaxAccumulator finds the maximum value.
+// Like [Max], it returns 0 when there are no values.
+type MaxAccumulator[N Number] struct {
+	max  N
+	seen bool
+}
+
+// Add adds values to the accumulator.
+func (ma *MaxAccumulator[N]) Add(values ...N) {
+	for _, v := range values {
+		if !ma.seen || v > ma.max {
+			ma.max, ma.seen = v, true
+		}
+	}
+}
+
+// Result returns the maximum of the values added so far.
+func (ma *MaxAccumulator[N]) Result() N { return ma.max }
+
+// Merge adds the maximum of other to the accumulator.
+func (ma *MaxAccumulator[N]) Merge(other Accumulator[N]) error {
+	o, err := mergeable(ma, other)
+	if err != nil {
+		return err
+	}
+	if o.seen {
+		ma.Add(o.max)
+	}
+	return nil
+}
+
+// RangeAccumulator calculates the difference between the maximum and minimum values.
+type RangeAccumulator[N Number] struct {
+	min MinAccumulator[N]
+	max MaxAccumulator[N]
+}
+
+// Add adds values to the accumulator.
+func (ra *RangeAccumulator[N]) Add(values ...N) {
+	ra.min.Add(values...)
+	ra.max.Add(values...)
+}
+
+// Result returns the range of the values added so far.
+func (ra *RangeAccumulator[N]) Result() N { return distance(ra.min.Result(), ra.max.Result()) }
+
+// Merge adds the minimum and maximum of other to the accumulator.
+func (ra *RangeAccumulator[N]) Merge(other Accumulator[N]) error {
+	o, err := mergeable(ra, other)
+	if err != nil {
+		return err
+	}
+	ra.min.Merge(&o.min)
+	return ra.max.Merge(&o.max)
+}
+
+// MeanAccumulator calculates the arithmetic mean of the values.
+// Like [Average], it truncates integer means.
+type MeanAccumulator[N Number] struct {
+	sum   float64
+	count int
+}
+
+// Add adds values to the accumulator.
+func (ma *MeanAccumulator[N]) Add(values ...N) {
+	for _, v := range values {
+		ma.sum += float64(v)
+	}
+	ma.count += len(values)
+}
+
+// Result returns the mean of the values added so far.
+func (ma *MeanAccumulator[N]) Result() N {
+	if ma.count == 0 {
+		return 0
+	}
+	return N(ma.sum / float64(ma.count))
+}
+
+// Merge adds the values of other to the accumulator.
+func (ma *MeanAccumulator[N]) Merge(other Accumulator[N]) error {
+	o, err := mergeable(ma, other)
+	if err != nil {
+		return err
+	}
+	ma.sum += o.sum
+	ma.count += o.count
+	return nil
+}
+
+// GeometricMeanAccumulator calculates the geometric mean of the values, using a sum of logarithms.
+type GeometricMeanAccumulator[N Number] struct {
+	logSum float64
+	count  int
+}
+
+// Add adds values to the accumulator.
+func (ga *GeometricMeanAccumulator[N]) Add(values ...N) {
+	for _, v := range values {
+		ga.logSum += math.Log(float64(v))
+	}
+	ga.count += len(values)
+}
+
+// Result returns the geometric mean of the values added so far.
+func (ga *GeometricMeanAccumulator[N]) Result() N {
+	if ga.count == 0 {
+		return 0
+	}
+	return fromFloat[N](math.Exp(ga.logSum / float64(ga.count)))
+}
+
+// Merge adds the values of other to the accumulator.
+func (ga *GeometricMeanAccumulator[N]) Merge(other Accumulator[N]) error {
+	o, err := mergeable(ga, other)
+	if err != nil {
+		return err
+	}
+	ga.logSum += o.logSum
+	ga.count += o.count
+	return nil
+}
+
+// HarmonicMeanAccumulator calculates the harmonic mean of the values.
+// Like [HarmonicMean], it returns 0 once a 0 value is added.
+type HarmonicMeanAccumulator[N Number] struct {
+	invSum  float64
+	count   int
+	hasZero bool
+}
+
+// Add adds values to the accumulator.
+func (ha *HarmonicMeanAccumulator[N]) Add(values ...N) {
+	for _, v := range values {
+		if v == 0 {
+			ha.hasZero = true
+			continue
+		}
+		ha.invSum += 1 / float64(v)
+	}
+	ha.count += len(values)
+}
+
+// Result returns the harmonic mean of the values added so far.
+func (ha *HarmonicMeanAccumulator[N]) Result() N {
+	if ha.hasZero || ha.invSum == 0 {
+		return 0
+	}
+	return fromFloat[N](float64(ha.count) / ha.invSum)
+}
+
+// Merge adds the values of other to the accumulator.
+func (ha *HarmonicMeanAccumulator[N]) Merge(other Accumulator[N]) error {
+	o, err := mergeable(ha, other)
+	if err != nil {
+		return err
+	}
+	ha.invSum += o.invSum
+	ha.count += o.count
+	ha.hasZero = ha.hasZero || o.hasZero
+	return nil
+}
+
+// VarianceAccumulator calculates the population variance of the values, using Welford's algorithm.
+type VarianceAccumulator[N Number] struct {
+	mean, m2 float64
+	count    int
+}
+
+// Add adds values to the accumulator.
+func (va *VarianceAccumulator[N]) Add(values ...N) {
+	for _, v := range values {
+		va.count++
+		delta := float64(v) - va.mean
+		va.mean += delta / float64(va.count)
+		va.m2 += delta * (float64(v) - va.mean)
+	}
+}
+
+// Result returns the population variance of the values added so far.
+func (va *VarianceAccumulator[N]) Result() N { return fromFloat[N](va.Variance()) }
+
+// Count returns the number of values added so far.
+func (va *VarianceAccumulator[N]) Count() int { return va.count }
+
+// Mean returns the mean of the values added so far.
+func (va *VarianceAccumulator[N]) Mean() float64 { return va.mean }
+
+// Variance returns the population variance of the values added so far.
+func (va *VarianceAccumulator[N]) Variance() float64 {
+	if va.count == 0 {
+		return 0
+	}
+	return va.m2 / float64(va.count)
+}
+
+// SampleVariance returns the sample variance of the values added so far.
+func (va *VarianceAccumulator[N]) SampleVariance() float64 {
+	if va.count < 2 {
+		return 0
+	}
+	return va.m2 / float64(va.count-1)
+}
+
+// StdDev returns the population standard deviation of the values added so far.
+func (va *VarianceAccumulator[N]) StdDev() float64 { return math.Sqrt(va.Variance()) }
+
+// Merge adds the values of other to the accumulator, using Chan's parallel algorithm.
+func (va *VarianceAccumulator[N]) Merge(other Accumulator[N]) error {
+	o, err := mergeable(va, other)
+	if err != nil {
+		return err
+	}
+	va.merge(o)
+	return nil
+}
+
+func (va *VarianceAccumulator[N]) merge(o *VarianceAccumulator[N]) {
+	if o.count == 0 {
+		return
+	}
+	count := va.count + o.count
+	delta := o.mean - va.mean
+	va.m2 += o.m2 + delta*delta*float64(va.count)*float64(o.count)/float64(count)
+	va.mean += delta * float64(o.count) / float64(count)
+	va.count = count
+}
+
+// StdDevAccumulator calculates the population standard deviation of the values.
+type StdDevAccumulator[N Number] struct {
+	VarianceAccumulator[N]
+}
+
+// Result returns the population standard deviation of the values added so far.
+func (sa *StdDevAccumulator[N]) Result() N { return fromFloat[N](sa.StdDev()) }
+
+// Merge adds the values of other to the accumulator.
+func (sa *StdDevAccumulator[N]) Merge(other Accumulator[N]) error {
+	o, err := mergeable(sa, other)
+	if err != nil {
+		return err
+	}
+	sa.merge(&o.VarianceAccumulator)
+	return nil
+}
+
+// quantileLevelSize is the number of values a [QuantileAccumulator] level holds before it is compacted.
+const quantileLevelSize = 128
+
+// QuantileAccumulator approximates a quantile of the values, using a compacting sketch.
+//
+// The result is exact until more than a few hundred values are added, interpolating linearly between the closest ranks
+// like [Percentile], after which its rank error stays within a few percent while using logarithmic memory.
+type QuantileAccumulator[N Number] struct {
+	// Quantile is the quantile to approximate, between 0 and 1.
+	Quantile float64
+
+	// levels[i] holds the values that stand for 2^i values each.
+	levels      [][]N
+	compactions int
+}
+
+// NewQuantileAccumulator creates a [QuantileAccumulator] for the quantile q, between 0 and 1.
+func NewQuantileAccumulator[N Number](q float64) *QuantileAccumulator[N] {
+	return &QuantileAccumulator[N]{Quantile: q}
+}
+
+// Add adds values to the accumulator.
+func (qa *QuantileAccumulator[N]) Add(values ...N) {
+	for _, v := range values {
+		qa.push(0, v)
+	}
+}
+
+// Result returns the approximate quantile of the values added so far.
+func (qa *QuantileAccumulator[N]) Result() N {
+	return qa.Value(qa.Quantile)
+}
+
+// Value returns the approximate q quantile of the values added so far.
+func (qa *QuantileAccumulator[N]) Value(q float64) N {
+	type weighted struct {
+		value  N
+		weight int
+	}
+	var items []weighted
+	var total int
+	for level, values := range qa.levels {
+		for _, v := range values {
+			items = append(items, weighted{v, 1 << level})
+			total += 1 << level
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	slices.SortFunc(items, func(a, b weighted) int { return cmp.Compare(a.value, b.value) })
+
+	// each item stands for weight consecutive ranks
+	rank := math.Max(0, math.Min(1, q)) * float64(total-1)
+	lo := int(math.Floor(rank))
+	frac := rank - float64(lo)
+	var cumulative int
+	for i, item := range items {
+		if cumulative += item.weight; cumulative <= lo {
+			continue
+		}
+		next := item.value
+		if cumulative == lo+1 && i+1 < len(items) {
+			next = items[i+1].value
+		}
+		if frac == 0 || next == item.value {
+			return item.value
+		}
+		return fromFloat[N](float64(item.value) + frac*(float64(next)-float64(item.value)))
+	}
+	return items[len(items)-1].value
+}
+
+// Merge adds the sketch of other to the accumulator.
+func (qa *QuantileAccumulator[N]) Merge(other Accumulator[N]) error {
+	o, err := mergeable(qa, other)
+	if err != nil {
+		return err
+	}
+	for level, values := range o.levels {
+		qa.push(level, values...)
+	}
+	return nil
+}
+
+func (qa *QuantileAccumulator[N]) push(level int, values ...N) {
+	for len(qa.levels) <= level {
+		qa.levels = append(qa.levels, nil)
+	}
+	qa.levels[level] = append(qa.levels[level], values...)
+	if len(qa.levels[level]) < quantileLevelSize {
+		return
+	}
+
+	// keep every other sorted value, alternating which, at double the weight
+	values = qa.levels[level]
+	slices.Sort(values)
+	var kept N
+	odd := len(values)%2 == 1
+	if odd {
+		kept, values = values[len(values)-1], values[:len(values)-1]
+	}
+	promoted := make([]N, 0, len(values)/2)
+	for i := qa.compactions % 2; i < len(values); i += 2 {
+		promoted = append(promoted, values[i])
+	}
+	qa.compactions++
+
+	qa.levels[level] = qa.levels[level][:0]
+	if odd {
+		qa.levels[level] = append(qa.levels[level], kept)
+	}
+	qa.push(level+1, promoted...)
+}
+
+// bufferedAccumulator keeps the values, for aggregators without an online form.
+type bufferedAccumulator[N Number] struct {
+	key       AggregatorKey
+	aggregate AggregatorFunc[N]
+	values    []N
+}
+
+func (ba *bufferedAccumulator[N]) Add(values ...N) { ba.values = append(ba.values, values...) }
+
+func (ba *bufferedAccumulator[N]) Result() N {
+	// aggregators may reorder their input
+	return ba.aggregate(slices.Clone(ba.values)...)
+}
+
+func (ba *bufferedAccumulator[N]) Merge(other Accumulator[N]) error {
+	o, err := mergeable(ba, other)
+	if err != nil {
+		return err
+	}
+	if o.key != ba.key {
+		return errs.Newf("cannot merge %s accumulator into %s accumulator", o.key, ba.key)
+	}
+	ba.Add(o.values...)
+	return nil
+}
+
+// mergeable casts other to the type of acc.
+func mergeable[A Accumulator[N], N Number](acc A, other Accumulator[N]) (A, error) {
+	o, ok := other.(A)
+	if !ok {
+		return o, errs.Newf("cannot merge %T into %T", other, acc)
+	}
+	return o, nil
+}
+
+// countOf converts count to N, saturating at the max value of N.
+func countOf[N Number](count int) N {
+	if isInteger[N]() && uint64(count) > uint64(MaxValue[N]()) {
+		return MaxValue[N]()
+	}
+	return N(count)
+}
+
+// fromFloat converts f to N, rounding it for integers.
+func fromFloat[N Number](f float64) N {
+	if isInteger[N]() {
+		return N(math.Round(f))
+	}
+	return N(f)
+}
@@ -0,0 +1,157 @@
+package mathutil_test
+
+import (
+	"math/rand"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/toolvox/utilgo/api"
+	"github.com/toolvox/utilgo/pkg/mathutil"
+)
+
+func Run_Test_Accumulator[N api.Number](t *testing.T, values []N) {
+	for _, key := range []mathutil.AggregatorKey{
+		mathutil.AggMax, mathutil.AggMin, mathutil.AggSum, mathutil.AggAverage, mathutil.AggProduct,
+		mathutil.AggGeometricMean, mathutil.AggHarmonicMean, mathutil.AggXenoSum,
+	} {
+		t.Run(string(key), func(t *testing.T) {
+			expected := mathutil.GetAggregator[N](key)(slices.Clone(values)...)
+
+			acc := mathutil.GetAccumulator[N](key)
+			for _, v := range values {
+				acc.Add(v)
+			}
+			require.InDelta(t, expected, acc.Result(), 1e-9)
+
+			half := len(values) / 2
+			left, right := mathutil.GetAccumulator[N](key), mathutil.GetAccumulator[N](key)
+			left.Add(values[:half]...)
+			right.Add(values[half:]...)
+			require.NoError(t, left.Merge(right))
+			require.InDelta(t, expected, left.Result(), 1e-9)
+		})
+	}
+}
+
+func Test_Accumulator(t *testing.T) {
+	t.Run("int", func(t *testing.T) { Run_Test_Accumulator(t, []int{4, 8, 1, 2, 16}) })
+	t.Run("float64", func(t *testing.T) { Run_Test_Accumulator(t, []float64{1.5, 2.5, 4, 0.5, 3}) })
+
+	t.Run("Empty", func(t *testing.T) {
+		for _, key := range []mathutil.AggregatorKey{
+			mathutil.AggCount, mathutil.AggMax, mathutil.AggMin, mathutil.AggSum, mathutil.AggAverage, mathutil.AggProduct,
+			mathutil.AggGeometricMean, mathutil.AggHarmonicMean, mathutil.AggVariance, mathutil.AggStdDev, mathutil.AggMedian,
+		} {
+			require.Zero(t, mathutil.GetAccumulator[int](key).Result(), key)
+		}
+	})
+
+	t.Run("Variance", func(t *testing.T) {
+		must := require.New(t)
+		var va mathutil.VarianceAccumulator[float64]
+		va.Add(2, 4, 4, 4, 5, 5, 7, 9)
+		must.Equal(8, va.Count())
+		must.InDelta(5, va.Mean(), 1e-12)
+		must.InDelta(4, va.Result(), 1e-12)
+		must.InDelta(32.0/7, va.SampleVariance(), 1e-12)
+
+		var left, right mathutil.StdDevAccumulator[float64]
+		left.Add(2, 4, 4)
+		right.Add(4, 5, 5, 7, 9)
+		must.NoError(left.Merge(&right))
+		must.InDelta(2, left.Result(), 1e-12)
+
+		must.InDelta(4, mathutil.GetAggregator[float64](mathutil.AggVariance)(2, 4, 4, 4, 5, 5, 7, 9), 1e-12)
+		must.Equal(2, mathutil.GetAggregator[int](mathutil.AggStdDev)(2, 4, 4, 4, 5, 5, 7, 9))
+	})
+
+	t.Run("Quantile", func(t *testing.T) {
+		must := require.New(t)
+		must.Equal(3, mathutil.GetAggregator[int](mathutil.AggMedian)(5, 1, 4, 2, 3))
+
+		rng := rand.New(rand.NewSource(1))
+		values := rng.Perm(100_000)
+		p90 := mathutil.NewQuantileAccumulator[int](0.9)
+		left, right := mathutil.NewQuantileAccumulator[int](0.9), mathutil.NewQuantileAccumulator[int](0.9)
+		for i, v := range values {
+			p90.Add(v)
+			if i%2 == 0 {
+				left.Add(v)
+			} else {
+				right.Add(v)
+			}
+		}
+		must.NoError(left.Merge(right))
+		must.InDelta(90_000, p90.Result(), 2_000)
+		must.InDelta(90_000, left.Result(), 2_000)
+		must.InDelta(50_000, p90.Value(0.5), 2_000)
+		must.InDelta(100_000, p90.Value(1), 2_000)
+	})
+
+	t.Run("MatchesAggregator", func(t *testing.T) {
+		for _, values := range [][]float64{{1, 2, 3, 4}, {7}, {5, 1, 4, 2, 3}, {1, 2, 2, 3, 10, 10, 11}} {
+			for _, key := range mathutil.AggregatorKeys() {
+				aggregate, err := mathutil.LookupAggregator[float64](key)
+				if err != nil {
+					continue
+				}
+				acc := mathutil.GetAccumulator[float64](key)
+				acc.Add(values...)
+				require.InDelta(t, aggregate(slices.Clone(values)...), acc.Result(), 1e-9, "%s of %v", key, values)
+
+				intAggregate, err := mathutil.LookupAggregator[int](key)
+				if err != nil {
+					continue
+				}
+				intValues := make([]int, len(values))
+				for i, v := range values {
+					intValues[i] = int(v)
+				}
+				intAcc := mathutil.GetAccumulator[int](key)
+				intAcc.Add(intValues...)
+				require.Equal(t, intAggregate(slices.Clone(intValues)...), intAcc.Result(), "%s of %v", key, intValues)
+			}
+		}
+
+		acc := mathutil.GetAccumulator[float64](mathutil.AggMedian)
+		acc.Add(1, 2, 3, 4)
+		require.Equal(t, 2.5, acc.Result())
+		acc = mathutil.GetAccumulator[float64](mathutil.AggP90)
+		acc.Add(1, 2, 3, 4)
+		require.InDelta(t, 3.7, acc.Result(), 1e-12)
+	})
+
+	t.Run("SaturatedProduct", func(t *testing.T) {
+		must := require.New(t)
+		var pa mathutil.ProductAccumulator[int8]
+		pa.Add(16, 16)
+		must.Equal(int8(127), pa.Result())
+		pa.Add(-1, 3, 5)
+		must.Equal(int8(-128), pa.Result())
+		pa.Add(0, 7)
+		must.Equal(int8(0), pa.Result(), "zero resets a saturated product")
+
+		var left, right mathutil.ProductAccumulator[int8]
+		left.Add(-2)
+		right.Add(100, 100)
+		must.NoError(left.Merge(&right))
+		must.Equal(int8(-128), left.Result())
+		left.Add(-1)
+		must.Equal(int8(127), left.Result())
+
+		var zero mathutil.ProductAccumulator[int8]
+		zero.Add(0)
+		must.NoError(zero.Merge(&right))
+		must.Equal(int8(0), zero.Result())
+	})
+
+	t.Run("MergeMismatch", func(t *testing.T) {
+		must := require.New(t)
+		var sum mathutil.SumAccumulator[int]
+		must.Error(sum.Merge(&mathutil.CountAccumulator[int]{}))
+		must.Error(mathutil.GetAccumulator[int](mathutil.AggXenoSum).Merge(&sum))
+		must.Panics(func() { mathutil.GetAccumulator[int]("Nope") })
+	})
+}
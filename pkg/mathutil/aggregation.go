@@ -3,6 +3,8 @@ package mathutil
 import (
 	"fmt"
 	"math"
+	"math/big"
+	"slices"
 	"sort"
 )
 
@@ -35,31 +37,30 @@ func Min[N Number](values ...N) N {
 }
 
 // Sum calculates the sum of the provided values.
+// Integer sums saturate at the bounds of N, see [CheckedSum] to detect it.
 func Sum[N Number](values ...N) N {
-	var sum N
-	for _, v := range values {
-		sum += v
-	}
+	sum, _ := CheckedSum(values...)
 	return sum
 }
 
 // Average calculates the average of the provided values.
+// Integer averages are truncated, and exact even when the sum does not fit in N.
 func Average[N Number](values ...N) N {
 	if len(values) == 0 {
 		return 0
 	}
-	return Sum(values...) / N(len(values))
+	sum, err := CheckedSum(values...)
+	if err != nil {
+		total := bigSum(values, new(big.Int))
+		return fromBig[N](total.Quo(total, big.NewInt(int64(len(values)))))
+	}
+	return sum / N(len(values))
 }
 
 // Product calculates the product of the provided values.
+// Integer products saturate at the bounds of N, see [CheckedProduct] to detect it.
 func Product[N Number](values ...N) N {
-	if len(values) == 0 {
-		return 0
-	}
-	var prod N = values[0]
-	for _, v := range values[1:] {
-		prod *= v
-	}
+	prod, _ := CheckedProduct(values...)
 	return prod
 }
 
@@ -115,6 +116,102 @@ func XenoSum[N Number](values ...N) N {
 	return N(sum)
 }
 
+// Median calculates the median of the provided values, averaging the middle two when their count is even.
+// Integer medians are rounded.
+func Median[N Number](values ...N) N {
+	return Percentile(50, values...)
+}
+
+// Percentile calculates the p-th percentile (0-100) of the provided values, interpolating linearly between the closest ranks.
+// Integer percentiles are rounded. [QuantileAccumulator] uses the same definition, as do [AggMedian], [AggP90] and [AggP99].
+func Percentile[N Number](p float64, values ...N) N {
+	if len(values) == 0 {
+		return 0
+	}
+	sorted := slices.Clone(values)
+	slices.Sort(sorted)
+
+	rank := math.Max(0, math.Min(1, p/100)) * float64(len(sorted)-1)
+	lo := int(math.Floor(rank))
+	if lo == len(sorted)-1 {
+		return sorted[lo]
+	}
+	frac := rank - float64(lo)
+	return fromFloat[N](float64(sorted[lo]) + frac*(float64(sorted[lo+1])-float64(sorted[lo])))
+}
+
+// Mode returns the most common of the provided values, or the smallest one among equally common values.
+func Mode[N Number](values ...N) N {
+	counts := make(map[N]int, len(values))
+	var mode N
+	for _, v := range values {
+		counts[v]++
+		if c, best := counts[v], counts[mode]; c > best || (c == best && v < mode) {
+			mode = v
+		}
+	}
+	return mode
+}
+
+// Variance calculates the population variance of the provided values.
+// Integer variances are rounded.
+func Variance[N Number](values ...N) N {
+	var va VarianceAccumulator[N]
+	va.Add(values...)
+	return va.Result()
+}
+
+// StdDev calculates the population standard deviation of the provided values.
+// Integer deviations are rounded.
+func StdDev[N Number](values ...N) N {
+	var sa StdDevAccumulator[N]
+	sa.Add(values...)
+	return sa.Result()
+}
+
+// Range calculates the difference between the maximum and minimum of the provided values, saturating at the max value of N.
+func Range[N Number](values ...N) N {
+	return distance(Min(values...), Max(values...))
+}
+
+// WeightedMean calculates the arithmetic mean of values, each counted weights times.
+// Integer means are rounded.
+func WeightedMean[N Number](values []N, weights []float64) (N, error) {
+	return weightedMean(values, weights, func(v float64) float64 { return v }, func(m float64) float64 { return m })
+}
+
+// WeightedGeometricMean calculates the geometric mean of values, each counted weights times.
+// Integer means are rounded.
+func WeightedGeometricMean[N Number](values []N, weights []float64) (N, error) {
+	return weightedMean(values, weights, math.Log, math.Exp)
+}
+
+// WeightedHarmonicMean calculates the harmonic mean of values, each counted weights times.
+// Integer means are rounded.
+func WeightedHarmonicMean[N Number](values []N, weights []float64) (N, error) {
+	inverse := func(v float64) float64 { return 1 / v }
+	return weightedMean(values, weights, inverse, inverse)
+}
+
+// weightedMean calculates the mean of values in the space mapped by to, and maps it back with from.
+func weightedMean[N Number](values []N, weights []float64, to, from func(float64) float64) (N, error) {
+	if len(values) != len(weights) {
+		return 0, fmt.Errorf("weighted mean: %d values with %d weights", len(values), len(weights))
+	}
+	var sum, totalWeight float64
+	for i, v := range values {
+		if weights[i] < 0 {
+			return 0, fmt.Errorf("weighted mean: negative weight %v", weights[i])
+		}
+		sum += weights[i] * to(float64(v))
+		totalWeight += weights[i]
+	}
+	if totalWeight == 0 {
+		return 0, nil
+	}
+	return fromFloat[N](from(sum / totalWeight)), nil
+}
+
 // Aggregator defines an interface for aggregating values.
 type Aggregator[N Number] interface {
 	Aggregate(values ...N) N
@@ -141,28 +238,62 @@ const (
 	AggGeometricMean AggregatorKey = "Geom"
 	AggHarmonicMean  AggregatorKey = "Harm"
 	AggXenoSum       AggregatorKey = "Xeno"
+	AggCount         AggregatorKey = "Count"
+	AggVariance      AggregatorKey = "Var"
+	AggStdDev        AggregatorKey = "StdDev"
+	AggMedian        AggregatorKey = "Median"
+	AggP90           AggregatorKey = "P90"
+	AggP99           AggregatorKey = "P99"
+	AggMode          AggregatorKey = "Mode"
+	AggRange         AggregatorKey = "Range"
 )
 
 // GetAggregator returns the AggregatorFunc corresponding to the given AggregatorKey.
+// It panics if the key is unknown, see [LookupAggregator] for a non-panicking lookup.
 func GetAggregator[N Number](key AggregatorKey) AggregatorFunc[N] {
+	agg, err := LookupAggregator[N](key)
+	if err != nil {
+		panic(err)
+	}
+	return agg
+}
+
+// builtinAggregator returns the AggregatorFunc of a built-in AggregatorKey.
+func builtinAggregator[N Number](key AggregatorKey) (AggregatorFunc[N], bool) {
 	switch key {
 	case AggMax:
-		return Max[N]
+		return Max[N], true
 	case AggMin:
-		return Min[N]
+		return Min[N], true
 	case AggSum:
-		return Sum[N]
+		return Sum[N], true
 	case AggAverage:
-		return Average[N]
+		return Average[N], true
 	case AggProduct:
-		return Product[N]
+		return Product[N], true
 	case AggGeometricMean:
-		return GeometricMean[N]
+		return GeometricMean[N], true
 	case AggHarmonicMean:
-		return HarmonicMean[N]
+		return HarmonicMean[N], true
 	case AggXenoSum:
-		return XenoSum[N]
+		return XenoSum[N], true
+	case AggCount:
+		return func(values ...N) N { return countOf[N](len(values)) }, true
+	case AggVariance:
+		return Variance[N], true
+	case AggStdDev:
+		return StdDev[N], true
+	case AggMedian:
+		return Median[N], true
+	case AggP90:
+		return func(values ...N) N { return Percentile(90, values...) }, true
+	case AggP99:
+		return func(values ...N) N { return Percentile(99, values...) }, true
+	case AggMode:
+		return Mode[N], true
+	case AggRange:
+		return Range[N], true
 	default:
-		panic(fmt.Errorf("unknown aggregator: %s", key))
+		return nil, false
 	}
 }
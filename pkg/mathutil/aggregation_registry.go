@@ -0,0 +1,208 @@
+package mathutil
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/toolvox/utilgo/pkg/errs"
+)
+
+// ErrUnknownAggregator is returned when looking up an AggregatorKey that is neither built-in nor registered.
+const ErrUnknownAggregator = errs.Error("unknown aggregator")
+
+// builtinKeys lists the built-in AggregatorKeys.
+var builtinKeys = []AggregatorKey{
+	AggMax, AggMin, AggSum, AggAverage, AggProduct, AggGeometricMean, AggHarmonicMean, AggXenoSum,
+	AggCount, AggVariance, AggStdDev, AggMedian, AggP90, AggP99, AggMode, AggRange,
+}
+
+// registry holds the user-defined aggregators, by key and by number type.
+var registry = struct {
+	sync.RWMutex
+	aggregators map[AggregatorKey]map[reflect.Type]any
+}{aggregators: map[AggregatorKey]map[reflect.Type]any{}}
+
+// RegisterAggregator registers agg under key for values of type N.
+// The same key can be registered once for each number type.
+//
+// It returns an error if the key is built-in, already registered for N, or not a valid key.
+func RegisterAggregator[N Number](key AggregatorKey, agg Aggregator[N]) error {
+	if key == "" || strings.ContainsAny(string(key), "() \t\n") {
+		return fmt.Errorf("registering aggregator '%s': invalid key", key)
+	}
+	for _, builtin := range builtinKeys {
+		if strings.EqualFold(string(builtin), string(key)) {
+			return fmt.Errorf("registering aggregator '%s': key is built-in", key)
+		}
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+	byType, ok := registry.aggregators[key]
+	if !ok {
+		byType = map[reflect.Type]any{}
+		registry.aggregators[key] = byType
+	}
+	numType := reflect.TypeFor[N]()
+	if _, ok := byType[numType]; ok {
+		return fmt.Errorf("registering aggregator '%s': already registered for %s", key, numType)
+	}
+	byType[numType] = AggregatorFunc[N](agg.Aggregate)
+	return nil
+}
+
+// LookupAggregator returns the AggregatorFunc corresponding to the given AggregatorKey, built-in or registered for N.
+// It returns an error wrapping [ErrUnknownAggregator] if there is none.
+func LookupAggregator[N Number](key AggregatorKey) (AggregatorFunc[N], error) {
+	if agg, ok := builtinAggregator[N](key); ok {
+		return agg, nil
+	}
+
+	registry.RLock()
+	defer registry.RUnlock()
+	if agg, ok := registry.aggregators[key][reflect.TypeFor[N]()]; ok {
+		return agg.(AggregatorFunc[N]), nil
+	}
+	if _, ok := registry.aggregators[key]; ok {
+		return nil, fmt.Errorf("%w: %s is not registered for %s", ErrUnknownAggregator, key, reflect.TypeFor[N]())
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownAggregator, key)
+}
+
+// AggregatorKeys returns the built-in keys followed by the registered ones, sorted.
+func AggregatorKeys() []AggregatorKey {
+	registry.RLock()
+	defer registry.RUnlock()
+	registered := make([]AggregatorKey, 0, len(registry.aggregators))
+	for key := range registry.aggregators {
+		registered = append(registered, key)
+	}
+	slices.Sort(registered)
+	return append(slices.Clone(builtinKeys), registered...)
+}
+
+// ParseAggregatorKey finds the built-in or registered AggregatorKey matching text, ignoring case.
+func ParseAggregatorKey(text string) (AggregatorKey, error) {
+	text = strings.TrimSpace(text)
+	for _, key := range AggregatorKeys() {
+		if strings.EqualFold(string(key), text) {
+			return key, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", ErrUnknownAggregator, text)
+}
+
+// String returns the key.
+// This method implements the [flag.Value] interface.
+func (k AggregatorKey) String() string { return string(k) }
+
+// Set parses the key, see [ParseAggregatorKey].
+// This method implements the [flag.Value] interface.
+func (k *AggregatorKey) Set(value string) error {
+	key, err := ParseAggregatorKey(value)
+	if err != nil {
+		return err
+	}
+	*k = key
+	return nil
+}
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (k AggregatorKey) MarshalText() ([]byte, error) { return []byte(k), nil }
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+func (k *AggregatorKey) UnmarshalText(text []byte) error { return k.Set(string(text)) }
+
+// AggregatorSpec is a chain of aggregators for nested groups, outermost first.
+// It is written as nested calls, like "Avg(Max)": the max of each group, averaged.
+type AggregatorSpec []AggregatorKey
+
+// ParseAggregatorSpec parses a spec like "Avg(Max)" or "Sum", matching keys as [ParseAggregatorKey] does.
+func ParseAggregatorSpec(text string) (AggregatorSpec, error) {
+	keys := strings.Split(text, "(")
+	last := keys[len(keys)-1]
+	keys[len(keys)-1] = strings.TrimRight(last, ") \t")
+	closers := strings.Count(last[len(keys[len(keys)-1]):], ")")
+	if closers != len(keys)-1 {
+		return nil, fmt.Errorf("parsing '%s' to aggregator spec: unbalanced parentheses", text)
+	}
+
+	spec := make(AggregatorSpec, len(keys))
+	for i, keyText := range keys {
+		key, err := ParseAggregatorKey(keyText)
+		if err != nil {
+			return nil, fmt.Errorf("parsing '%s' to aggregator spec: %w", text, err)
+		}
+		spec[i] = key
+	}
+	return spec, nil
+}
+
+// String returns the spec as nested calls, like "Avg(Max)".
+func (s AggregatorSpec) String() string {
+	if len(s) == 0 {
+		return ""
+	}
+	keys := make([]string, len(s))
+	for i, key := range s {
+		keys[i] = string(key)
+	}
+	return strings.Join(keys, "(") + strings.Repeat(")", len(s)-1)
+}
+
+// Set parses the spec, see [ParseAggregatorSpec].
+// This method implements the [flag.Value] interface.
+func (s *AggregatorSpec) Set(value string) error {
+	spec, err := ParseAggregatorSpec(value)
+	if err != nil {
+		return err
+	}
+	*s = spec
+	return nil
+}
+
+// MarshalText implements the [encoding.TextMarshaler] interface.
+func (s AggregatorSpec) MarshalText() ([]byte, error) { return []byte(s.String()), nil }
+
+// UnmarshalText implements the [encoding.TextUnmarshaler] interface.
+func (s *AggregatorSpec) UnmarshalText(text []byte) error { return s.Set(string(text)) }
+
+// LookupSpec returns the AggregatorFuncs of the spec for N, outermost first.
+func LookupSpec[N Number](spec AggregatorSpec) ([]AggregatorFunc[N], error) {
+	if len(spec) == 0 {
+		return nil, errs.New("empty aggregator spec")
+	}
+	aggs := make([]AggregatorFunc[N], len(spec))
+	for i, key := range spec {
+		agg, err := LookupAggregator[N](key)
+		if err != nil {
+			return nil, err
+		}
+		aggs[i] = agg
+	}
+	return aggs, nil
+}
+
+// AggregateGroups aggregates each group with the innermost aggregator of the spec, and their results with the outer one.
+// A single-key spec aggregates all the values of the groups together.
+func AggregateGroups[N Number](spec AggregatorSpec, groups ...[]N) (N, error) {
+	aggs, err := LookupSpec[N](spec)
+	if err != nil {
+		return 0, err
+	}
+	switch len(aggs) {
+	case 1:
+		return aggs[0](slices.Concat(groups...)...), nil
+	case 2:
+		results := make([]N, len(groups))
+		for i, group := range groups {
+			results[i] = aggs[1](slices.Clone(group)...)
+		}
+		return aggs[0](results...), nil
+	default:
+		return 0, fmt.Errorf("aggregating groups: spec '%s' needs %d levels of groups", spec, len(spec))
+	}
+}
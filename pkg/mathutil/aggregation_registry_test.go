@@ -0,0 +1,112 @@
+package mathutil_test
+
+import (
+	"encoding/json"
+	"flag"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/toolvox/utilgo/pkg/mathutil"
+)
+
+func Test_Aggregator_Registry(t *testing.T) {
+	t.Run("Register", func(t *testing.T) {
+		must := require.New(t)
+		const sumSquares mathutil.AggregatorKey = "SumSq"
+		must.NoError(mathutil.RegisterAggregator(sumSquares, mathutil.AggregatorFunc[int](func(values ...int) int {
+			var res int
+			for _, v := range values {
+				res += v * v
+			}
+			return res
+		})))
+		must.Equal(14, mathutil.GetAggregator[int](sumSquares)(1, 2, 3))
+		must.Equal(14, mathutil.Accumulated(func() mathutil.Accumulator[int] { return mathutil.GetAccumulator[int](sumSquares) })(1, 2, 3))
+		must.Contains(mathutil.AggregatorKeys(), sumSquares)
+
+		_, err := mathutil.LookupAggregator[float64](sumSquares)
+		must.ErrorIs(err, mathutil.ErrUnknownAggregator)
+
+		must.Error(mathutil.RegisterAggregator(sumSquares, mathutil.AggregatorFunc[int](mathutil.Sum[int])))
+		must.Error(mathutil.RegisterAggregator("max", mathutil.AggregatorFunc[int](mathutil.Sum[int])))
+		must.Error(mathutil.RegisterAggregator("A(B)", mathutil.AggregatorFunc[int](mathutil.Sum[int])))
+		must.NoError(mathutil.RegisterAggregator(sumSquares, mathutil.AggregatorFunc[float64](mathutil.Sum[float64])))
+	})
+
+	t.Run("Lookup", func(t *testing.T) {
+		must := require.New(t)
+		agg, err := mathutil.LookupAggregator[int](mathutil.AggMax)
+		must.NoError(err)
+		must.Equal(3, agg(1, 3, 2))
+
+		_, err = mathutil.LookupAggregator[int]("Nope")
+		must.ErrorIs(err, mathutil.ErrUnknownAggregator)
+		must.EqualError(err, "unknown aggregator: Nope")
+		must.PanicsWithError("unknown aggregator: Nope", func() { mathutil.GetAggregator[int]("Nope") })
+	})
+
+	t.Run("Key", func(t *testing.T) {
+		must := require.New(t)
+		var key mathutil.AggregatorKey
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.Var(&key, "agg", "aggregation")
+		must.NoError(fs.Parse([]string{"-agg", "avg"}))
+		must.Equal(mathutil.AggAverage, key)
+		must.Error(key.Set("Nope"))
+
+		var config struct {
+			Agg mathutil.AggregatorKey `json:"agg"`
+		}
+		must.NoError(json.Unmarshal([]byte(`{"agg":"p90"}`), &config))
+		must.Equal(mathutil.AggP90, config.Agg)
+		must.Error(json.Unmarshal([]byte(`{"agg":"p95"}`), &config))
+	})
+
+	t.Run("Spec", func(t *testing.T) {
+		tests := []struct {
+			text     string
+			expected mathutil.AggregatorSpec
+		}{
+			{"Sum", mathutil.AggregatorSpec{mathutil.AggSum}},
+			{"avg(max)", mathutil.AggregatorSpec{mathutil.AggAverage, mathutil.AggMax}},
+			{" Max( Sum( Count ) ) ", mathutil.AggregatorSpec{mathutil.AggMax, mathutil.AggSum, mathutil.AggCount}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.text, func(t *testing.T) {
+				spec, err := mathutil.ParseAggregatorSpec(tt.text)
+				require.NoError(t, err)
+				require.Equal(t, tt.expected, spec)
+
+				again, err := mathutil.ParseAggregatorSpec(spec.String())
+				require.NoError(t, err)
+				require.Equal(t, spec, again)
+			})
+		}
+
+		for _, text := range []string{"", "Avg(Max", "Avg(Max))", "Avg)(Max", "Avg(Nope)", "Avg(Max)x"} {
+			t.Run(text, func(t *testing.T) {
+				_, err := mathutil.ParseAggregatorSpec(text)
+				require.Error(t, err)
+			})
+		}
+	})
+
+	t.Run("AggregateGroups", func(t *testing.T) {
+		must := require.New(t)
+		groups := [][]int{{1, 5, 3}, {2, 9}, {4}}
+
+		res, err := mathutil.AggregateGroups(mathutil.AggregatorSpec{mathutil.AggAverage, mathutil.AggMax}, groups...)
+		must.NoError(err)
+		must.Equal(6, res)
+
+		res, err = mathutil.AggregateGroups(mathutil.AggregatorSpec{mathutil.AggSum}, groups...)
+		must.NoError(err)
+		must.Equal(24, res)
+
+		_, err = mathutil.AggregateGroups(mathutil.AggregatorSpec{mathutil.AggSum, mathutil.AggSum, mathutil.AggSum}, groups...)
+		must.Error(err)
+		_, err = mathutil.AggregateGroups[int](nil, groups...)
+		must.Error(err)
+	})
+}
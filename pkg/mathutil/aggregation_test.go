@@ -221,3 +221,162 @@ func TestXenoSumFloat64(t *testing.T) {
 	}
 	runTestCases(t, tests, mathutil.AggXenoSum)
 }
+
+func TestMedianInt(t *testing.T) {
+	tests := []testCase[int]{
+		{"odd count", []int{5, 1, 3}, 3},
+		{"even count", []int{4, 1, 3, 2}, 3},
+		{"single element", []int{7}, 7},
+		{"identical numbers", []int{2, 2, 2}, 2},
+		{"empty slice", []int{}, 0},
+	}
+	runTestCases(t, tests, mathutil.AggMedian)
+}
+
+func TestMedianFloat64(t *testing.T) {
+	tests := []testCase[float64]{
+		{"odd count", []float64{2.5, 0.5, 1.5}, 1.5},
+		{"even count", []float64{4, 1, 3, 2}, 2.5},
+		{"empty slice", []float64{}, 0},
+	}
+	runTestCases(t, tests, mathutil.AggMedian)
+}
+
+func TestPercentileFloat64(t *testing.T) {
+	values := []float64{10, 20, 30, 40, 50, 60, 70, 80, 90, 100, 110}
+	runTestCases(t, []testCase[float64]{{"p90", values, 100}, {"empty slice", []float64{}, 0}}, mathutil.AggP90)
+	runTestCases(t, []testCase[float64]{{"p99", values, 109}, {"single element", []float64{3}, 3}}, mathutil.AggP99)
+	require.Equal(t, 10.0, mathutil.Percentile(-5, values...))
+	require.Equal(t, 110.0, mathutil.Percentile(200, values...))
+}
+
+func TestModeInt(t *testing.T) {
+	tests := []testCase[int]{
+		{"single mode", []int{1, 2, 2, 3}, 2},
+		{"tie picks smallest", []int{3, 3, -1, -1, 5}, -1},
+		{"zero is common", []int{0, 0, 4}, 0},
+		{"single element", []int{9}, 9},
+		{"empty slice", []int{}, 0},
+	}
+	runTestCases(t, tests, mathutil.AggMode)
+}
+
+func TestVarianceFloat64(t *testing.T) {
+	tests := []testCase[float64]{
+		{"textbook", []float64{2, 4, 4, 4, 5, 5, 7, 9}, 4},
+		{"single element", []float64{3.5}, 0},
+		{"empty slice", []float64{}, 0},
+	}
+	runTestCases(t, tests, mathutil.AggVariance)
+}
+
+func TestStdDevInt(t *testing.T) {
+	tests := []testCase[int]{
+		{"textbook", []int{2, 4, 4, 4, 5, 5, 7, 9}, 2},
+		{"rounded", []int{1, 4}, 2},
+		{"empty slice", []int{}, 0},
+	}
+	runTestCases(t, tests, mathutil.AggStdDev)
+}
+
+func TestRangeInt8(t *testing.T) {
+	tests := []testCase[int8]{
+		{"mixed numbers", []int8{-3, 7, 1}, 10},
+		{"saturated", []int8{-128, 127}, 127},
+		{"single element", []int8{5}, 0},
+		{"empty slice", []int8{}, 0},
+	}
+	runTestCases(t, tests, mathutil.AggRange)
+}
+
+func TestCountUint8(t *testing.T) {
+	tests := []testCase[uint8]{
+		{"some", []uint8{4, 4, 4}, 3},
+		{"saturated", make([]uint8, 300), 255},
+		{"empty slice", []uint8{}, 0},
+	}
+	runTestCases(t, tests, mathutil.AggCount)
+}
+
+func TestOverflowInt8(t *testing.T) {
+	runTestCases(t, []testCase[int8]{
+		{"saturates up", []int8{100, 100, -50}, 127},
+		{"saturates down", []int8{-100, -100}, -128},
+		{"fits", []int8{100, -50, 77}, 127},
+	}, mathutil.AggSum)
+	runTestCases(t, []testCase[int8]{
+		{"exact despite overflow", []int8{100, 100, 100}, 100},
+		{"negative", []int8{-100, -100, -99}, -99},
+	}, mathutil.AggAverage)
+	runTestCases(t, []testCase[int8]{
+		{"saturates up", []int8{-128, -1}, 127},
+		{"saturates down", []int8{64, -3}, -128},
+		{"fits", []int8{-64, 2}, -128},
+	}, mathutil.AggProduct)
+}
+
+func TestOverflowUint16(t *testing.T) {
+	must := require.New(t)
+	sum, err := mathutil.CheckedSum[uint16](60_000, 10_000)
+	must.ErrorIs(err, mathutil.ErrOverflow)
+	must.Equal(uint16(65_535), sum)
+
+	sum, err = mathutil.CheckedSum[uint16](60_000, 5_000)
+	must.NoError(err)
+	must.Equal(uint16(65_000), sum)
+
+	prod, err := mathutil.CheckedProduct[uint16](300, 300)
+	must.ErrorIs(err, mathutil.ErrOverflow)
+	must.Equal(uint16(65_535), prod)
+
+	must.Equal(uint16(50_000), mathutil.Average[uint16](60_000, 40_000))
+
+	int8Sum, err := mathutil.CheckedSum[int8](100, 100, -100)
+	must.NoError(err, "intermediate sums may overflow")
+	must.Equal(int8(100), int8Sum)
+	int8Sum, err = mathutil.CheckedSum[int8](100, 100, -50)
+	must.ErrorIs(err, mathutil.ErrOverflow)
+	must.Equal(int8(127), int8Sum)
+
+	int8Prod, err := mathutil.CheckedProduct[int8](100, 100, 0)
+	must.NoError(err, "intermediate products may overflow")
+	must.Zero(int8Prod)
+	int8Prod, err = mathutil.CheckedProduct[int8](16, 16, -1, 0, 3)
+	must.NoError(err)
+	must.Zero(int8Prod)
+	int8Prod, err = mathutil.CheckedProduct[int8](-128, -1, -1)
+	must.NoError(err)
+	must.Equal(int8(-128), int8Prod)
+	int8Prod, err = mathutil.CheckedProduct[int8](16, 16, -1)
+	must.ErrorIs(err, mathutil.ErrOverflow)
+	must.Equal(int8(-128), int8Prod)
+
+	var acc mathutil.SumAccumulator[uint16]
+	acc.Add(60_000, 60_000)
+	must.Equal(uint16(65_535), acc.Result())
+	acc.Add(0)
+	var other mathutil.SumAccumulator[uint16]
+	other.Add(1)
+	must.NoError(other.Merge(&acc))
+	must.Equal(uint16(65_535), other.Result())
+}
+
+func TestWeightedMean(t *testing.T) {
+	must := require.New(t)
+	mean, err := mathutil.WeightedMean([]float64{1, 2, 3}, []float64{3, 0, 1})
+	must.NoError(err)
+	must.InDelta(1.5, mean, 1e-12)
+
+	geom, err := mathutil.WeightedGeometricMean([]float64{2, 8}, []float64{1, 1})
+	must.NoError(err)
+	must.InDelta(4, geom, 1e-12)
+
+	harm, err := mathutil.WeightedHarmonicMean([]int{1, 4}, []float64{1, 2})
+	must.NoError(err)
+	must.Equal(2, harm)
+
+	_, err = mathutil.WeightedMean([]int{1, 2}, []float64{1})
+	must.Error(err)
+	_, err = mathutil.WeightedMean([]int{1, 2}, []float64{1, -1})
+	must.Error(err)
+}
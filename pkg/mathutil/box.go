@@ -0,0 +1,219 @@
+package mathutil
+
+import (
+	"iter"
+
+	"github.com/toolvox/utilgo/api"
+	"github.com/toolvox/utilgo/pkg/errs"
+)
+
+// Box is an axis-aligned region in N dimensions, made of one interval per axis.
+// A box is empty when any of its axes is empty.
+//
+// Operations between boxes of different dimensions panic.
+type Box[N Number] []api.Interval[N]
+
+// NewBox creates a [Box] from the intervals of its axes.
+func NewBox[N Number](axes ...api.Interval[N]) Box[N] {
+	return Box[N](axes)
+}
+
+// Dims returns the number of dimensions of the [Box].
+func (b Box[N]) Dims() int { return len(b) }
+
+// IsEmpty checks whether the [Box] has no points.
+func (b Box[N]) IsEmpty() bool {
+	if len(b) == 0 {
+		return true
+	}
+	for _, axis := range b {
+		if axis == nil || axis.IsEmpty() {
+			return true
+		}
+	}
+	return false
+}
+
+// Volume returns the product of the sizes of the axes, saturating at MaxValue.
+func (b Box[N]) Volume() N {
+	if b.IsEmpty() {
+		return 0
+	}
+	var res N = 1
+	for _, axis := range b {
+		res = mulSat(res, axis.Len())
+	}
+	return res
+}
+
+// Contains checks whether the point is in the [Box].
+// Points of different dimensions are never contained.
+func (b Box[N]) Contains(point ...N) bool {
+	if len(point) != len(b) || b.IsEmpty() {
+		return false
+	}
+	for i, axis := range b {
+		if !axis.Contains(point[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Overlaps checks whether the [Box]es have any point in common.
+func (b Box[N]) Overlaps(other Box[N]) bool {
+	return !b.Intersection(other).IsEmpty()
+}
+
+// Equals checks whether the [Box]es are made of equal axes.
+// Empty boxes are all equal.
+func (b Box[N]) Equals(other Box[N]) bool {
+	if b.IsEmpty() || other.IsEmpty() {
+		return b.IsEmpty() && other.IsEmpty()
+	}
+	b.mustMatch(other)
+	for i, axis := range b {
+		if !axis.Equals(other[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+// Intersection creates the [Box] of the points in both boxes.
+func (b Box[N]) Intersection(other Box[N]) Box[N] {
+	b.mustMatch(other)
+	res := make(Box[N], len(b))
+	for i, axis := range b {
+		res[i] = axis.Intersection(other[i])
+	}
+	return res
+}
+
+// Difference splits the points in the [Box] but not in other into disjoint boxes with simple axes.
+func (b Box[N]) Difference(other Box[N]) []Box[N] {
+	b.mustMatch(other)
+	if b.IsEmpty() {
+		return nil
+	}
+	if !b.Overlaps(other) {
+		return b.Split()
+	}
+
+	// peel off the slabs outside of other, one axis at a time
+	var res []Box[N]
+	remaining := append(Box[N]{}, b...)
+	for i, axis := range remaining {
+		for outside := range axis.Difference(other[i]).SubIntervals() {
+			slab := append(Box[N]{}, remaining...)
+			slab[i] = outside
+			res = append(res, slab.Split()...)
+		}
+		remaining[i] = axis.Intersection(other[i])
+	}
+	return res
+}
+
+// Union splits the points in either [Box] into disjoint boxes with simple axes.
+func (b Box[N]) Union(other Box[N]) []Box[N] {
+	b.mustMatch(other)
+	return append(b.Split(), other.Difference(b)...)
+}
+
+// Split splits the [Box] into disjoint boxes whose axes are all simple intervals.
+func (b Box[N]) Split() []Box[N] {
+	if b.IsEmpty() {
+		return nil
+	}
+	res := []Box[N]{{}}
+	for _, axis := range b {
+		var next []Box[N]
+		for _, partial := range res {
+			for subInt := range axis.SubIntervals() {
+				next = append(next, append(append(Box[N]{}, partial...), subInt))
+			}
+		}
+		res = next
+	}
+	return res
+}
+
+// Resize resizes each axis to its new size.
+// The grow modes are applied per axis, a single grow mode applies to all axes.
+func (b Box[N]) Resize(newSizes []N, growModes ...api.GrowFlags) Box[N] {
+	if len(newSizes) != len(b) {
+		panic(errs.Newf("box resize: %d sizes for %d dimensions", len(newSizes), len(b)))
+	}
+	res := make(Box[N], len(b))
+	for i, axis := range b {
+		res[i] = axis.Resize(newSizes[i], growModeOf(growModes, i))
+	}
+	return res
+}
+
+// Scale scales each axis by scale.
+// The grow modes are applied per axis, a single grow mode applies to all axes.
+func (b Box[N]) Scale(scale float64, growModes ...api.GrowFlags) Box[N] {
+	res := make(Box[N], len(b))
+	for i, axis := range b {
+		res[i] = axis.Scale(scale, growModeOf(growModes, i))
+	}
+	return res
+}
+
+// Translate moves each axis by its offset.
+func (b Box[N]) Translate(offsets []N, back bool) Box[N] {
+	if len(offsets) != len(b) {
+		panic(errs.Newf("box translate: %d offsets for %d dimensions", len(offsets), len(b)))
+	}
+	res := make(Box[N], len(b))
+	for i, axis := range b {
+		res[i] = axis.Translate(offsets[i], back)
+	}
+	return res
+}
+
+// Points iterates over the lattice points of the [Box], step apart on every axis.
+// The last axis changes fastest, and every point is a new slice.
+func (b Box[N]) Points(step N) iter.Seq[[]N] {
+	return func(yield func([]N) bool) {
+		if b.IsEmpty() {
+			return
+		}
+		b.points(make([]N, 0, len(b)), step, yield)
+	}
+}
+
+func (b Box[N]) points(prefix []N, step N, yield func([]N) bool) bool {
+	axis := b[len(prefix)]
+	for v := range axis.All(step) {
+		point := append(prefix[:len(prefix):len(prefix)], v)
+		if len(point) == len(b) {
+			if !yield(point) {
+				return false
+			}
+			continue
+		}
+		if !b.points(point, step, yield) {
+			return false
+		}
+	}
+	return true
+}
+
+func (b Box[N]) mustMatch(other Box[N]) {
+	if len(b) != len(other) {
+		panic(errs.Newf("box dimensions mismatch: %d != %d", len(b), len(other)))
+	}
+}
+
+func growModeOf(growModes []api.GrowFlags, axis int) api.GrowFlags {
+	switch {
+	case len(growModes) == 0:
+		return api.GROW_BOTH_OVERFLOW_RIGHT
+	case axis < len(growModes):
+		return growModes[axis]
+	default:
+		return growModes[len(growModes)-1]
+	}
+}
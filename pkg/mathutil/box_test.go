@@ -0,0 +1,127 @@
+package mathutil_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/toolvox/utilgo/api"
+	"github.com/toolvox/utilgo/pkg/mathutil"
+)
+
+func box(bounds ...int) mathutil.Box[int] {
+	var res mathutil.Box[int]
+	for i := 0; i < len(bounds); i += 2 {
+		res = append(res, mathutil.NewClosed(bounds[i], bounds[i+1]))
+	}
+	return res
+}
+
+func volumeOf(boxes []mathutil.Box[int]) int {
+	var res int
+	for _, b := range boxes {
+		res += b.Volume()
+	}
+	return res
+}
+
+func requireDisjoint(t *testing.T, boxes []mathutil.Box[int]) {
+	t.Helper()
+	for i, a := range boxes {
+		for _, b := range boxes[i+1:] {
+			require.False(t, a.Overlaps(b), "%v overlaps %v", a, b)
+		}
+	}
+}
+
+func Test_Box(t *testing.T) {
+	t.Run("Basics", func(t *testing.T) {
+		must := require.New(t)
+		b := box(0, 9, 0, 4)
+		must.Equal(2, b.Dims())
+		must.Equal(50, b.Volume())
+		must.True(b.Contains(9, 0))
+		must.False(b.Contains(10, 0))
+		must.False(b.Contains(1))
+		must.True(box(0, 1, 5, 4).IsEmpty())
+		must.Zero(box(0, 1, 5, 4).Volume())
+		must.True(mathutil.NewBox[int]().IsEmpty())
+		must.Equal(mathutil.MaxValue[int8](), mathutil.NewBox(mathutil.NewClosed[int8](0, 99), mathutil.NewClosed[int8](0, 99)).Volume())
+	})
+
+	t.Run("Intersection", func(t *testing.T) {
+		must := require.New(t)
+		must.True(box(2, 5, 3, 4).Equals(box(0, 5, 0, 4).Intersection(box(2, 9, 3, 9))))
+		must.True(box(0, 1, 0, 1).Intersection(box(5, 6, 0, 1)).IsEmpty())
+		must.True(box(0, 1, 0, 1).Overlaps(box(1, 2, 1, 2)))
+		must.False(box(0, 1, 0, 1).Overlaps(box(2, 3, 0, 1)))
+	})
+
+	t.Run("Difference", func(t *testing.T) {
+		tests := []struct {
+			name        string
+			a, b        mathutil.Box[int]
+			expectedVol int
+			expectedLen int
+		}{
+			{"disjoint", box(0, 4, 0, 4), box(10, 14, 0, 4), 25, 1},
+			{"covered", box(2, 3, 2, 3), box(0, 4, 0, 4), 0, 0},
+			{"hole", box(0, 4, 0, 4), box(1, 3, 1, 3), 16, 4},
+			{"corner", box(0, 4, 0, 4), box(3, 9, 3, 9), 21, 2},
+			{"edge", box(0, 4, 0, 4), box(-1, 5, 2, 9), 10, 1},
+			{"3d", box(0, 2, 0, 2, 0, 2), box(1, 1, 1, 1, 1, 1), 26, 6},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				res := tt.a.Difference(tt.b)
+				require.Len(t, res, tt.expectedLen)
+				require.Equal(t, tt.expectedVol, volumeOf(res))
+				requireDisjoint(t, res)
+				for _, r := range res {
+					require.False(t, r.Overlaps(tt.b))
+				}
+			})
+		}
+	})
+
+	t.Run("Union", func(t *testing.T) {
+		must := require.New(t)
+		res := box(0, 4, 0, 4).Union(box(3, 9, 3, 9))
+		requireDisjoint(t, res)
+		must.Equal(25+49-4, volumeOf(res))
+
+		res = box(0, 4, 0, 4).Union(box(1, 2, 1, 2))
+		must.Len(res, 1)
+
+		split := mathutil.NewBox(merged(0, 1, 5, 6), merged(0, 0, 3, 3)).Split()
+		must.Len(split, 4)
+		requireDisjoint(t, split)
+
+		must.Panics(func() { box(0, 1).Union(box(0, 1, 0, 1)) })
+	})
+
+	t.Run("Resize", func(t *testing.T) {
+		must := require.New(t)
+		must.True(box(1, 4, 4, 8).Equals(box(3, 4, 4, 5).Resize([]int{4, 5}, api.GROW_LEFT_OVERFLOW_RIGHT, api.GROW_RIGHT_OVERFLOW_LEFT)))
+		must.True(box(1, 6, 1, 6).Equals(box(2, 5, 2, 5).Resize([]int{6, 6})))
+		must.True(box(0, 7, 0, 7).Equals(box(2, 5, 2, 5).Scale(2, api.GROW_BOTH_OVERFLOW_RIGHT)))
+		must.True(box(1, 3, 9, 11).Equals(box(0, 2, 10, 12).Translate([]int{1, -1}, false)))
+		must.Panics(func() { box(0, 1).Resize([]int{1, 2}) })
+	})
+
+	t.Run("Points", func(t *testing.T) {
+		must := require.New(t)
+		must.Equal([][]int{{0, 5}, {0, 6}, {1, 5}, {1, 6}}, slices.Collect(box(0, 1, 5, 6).Points(1)))
+		must.Equal([][]int{{0, 0}, {0, 2}, {2, 0}, {2, 2}}, slices.Collect(box(0, 2, 0, 2).Points(2)))
+		must.Empty(slices.Collect(box(0, 1, 2, 1).Points(1)))
+
+		var count int
+		for range box(0, 999, 0, 999).Points(1) {
+			if count++; count == 10 {
+				break
+			}
+		}
+		must.Equal(10, count)
+	})
+}
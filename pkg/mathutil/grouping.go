@@ -0,0 +1,173 @@
+package mathutil
+
+import (
+	"cmp"
+	"fmt"
+
+	"github.com/toolvox/utilgo/pkg/maputil"
+)
+
+// Group holds the aggregate of a group of records, and its sub-groups.
+type Group[K cmp.Ordered, N Number] struct {
+	// Key is the key shared by the records of the group, the zero value for the top group.
+	Key K
+	// Value is the aggregate of the group, its sub-total.
+	Value N
+	// Count is the number of records in the group.
+	Count int
+	// Groups are the sub-groups, sorted by key. Groups of the last key selector have none.
+	Groups []*Group[K, N]
+}
+
+// GroupBy groups records by each of the key selectors in turn, and aggregates the value of the records of every group.
+// The returned top group holds the grand total.
+func GroupBy[R any, K cmp.Ordered, N Number](records []R, value func(R) N, agg Aggregator[N], keys ...func(R) K) *Group[K, N] {
+	levels := make([]groupLevel[N], len(keys)+1)
+	for i := range levels {
+		levels[i] = groupLevel[N]{aggregate: agg.Aggregate}
+	}
+	var key K
+	return groupRecords(records, value, levels, keys, key)
+}
+
+// GroupBySpec groups records like [GroupBy], using the aggregators of the spec for nested grouping.
+//
+// The innermost aggregator of the spec aggregates the values of the records of the last groups,
+// and every outer aggregator aggregates the results of the groups under it.
+// Groups above the outermost aggregator keep using it. A single-key spec is the same as [GroupBy].
+//
+// For example, "Avg(Max)" grouped by day and hour is the average of the hourly maximums of each day.
+func GroupBySpec[R any, K cmp.Ordered, N Number](records []R, value func(R) N, spec AggregatorSpec, keys ...func(R) K) (*Group[K, N], error) {
+	if len(spec) > len(keys)+1 {
+		return nil, fmt.Errorf("grouping by spec '%s': %d aggregators for %d key selectors", spec, len(spec), len(keys))
+	}
+	aggs, err := LookupSpec[N](spec)
+	if err != nil {
+		return nil, err
+	}
+	if len(aggs) == 1 {
+		return GroupBy(records, value, aggs[0], keys...), nil
+	}
+
+	levels := make([]groupLevel[N], len(keys)+1)
+	for depth := range levels {
+		index := max(0, len(aggs)-len(levels)+depth)
+		levels[depth] = groupLevel[N]{aggregate: aggs[index], rollup: depth < len(keys)}
+	}
+	var key K
+	return groupRecords(records, value, levels, keys, key), nil
+}
+
+// Find returns the sub-group at the path of keys, or nil if there is none.
+func (g *Group[K, N]) Find(path ...K) *Group[K, N] {
+	for _, key := range path {
+		var next *Group[K, N]
+		for _, sub := range g.Groups {
+			if sub.Key == key {
+				next = sub
+				break
+			}
+		}
+		if next == nil {
+			return nil
+		}
+		g = next
+	}
+	return g
+}
+
+// Walk calls fn for the group and every sub-group, depth first, with the keys leading to it.
+// Returning false from fn skips the sub-groups of the group.
+func (g *Group[K, N]) Walk(fn func(path []K, group *Group[K, N]) bool) {
+	g.walk(nil, fn)
+}
+
+func (g *Group[K, N]) walk(path []K, fn func(path []K, group *Group[K, N]) bool) {
+	if !fn(path, g) {
+		return
+	}
+	for _, sub := range g.Groups {
+		sub.walk(append(path[:len(path):len(path)], sub.Key), fn)
+	}
+}
+
+// PivotTable holds the aggregates of records grouped by a row key and a column key.
+type PivotTable[K cmp.Ordered, N Number] struct {
+	// Rows and Cols are the sorted row and column keys.
+	Rows, Cols []K
+	// Cells holds the aggregate of every row and column with records, as Cells[row][col].
+	Cells map[K]map[K]N
+	// RowTotals and ColTotals hold the aggregates of every row and column.
+	RowTotals, ColTotals map[K]N
+	// Total is the aggregate of all records.
+	Total N
+}
+
+// Pivot aggregates the value of records by their row and column keys, with sub-totals for every row and column.
+func Pivot[R any, K cmp.Ordered, N Number](records []R, value func(R) N, agg Aggregator[N], row, col func(R) K) *PivotTable[K, N] {
+	byRow := GroupBy(records, value, agg, row, col)
+	byCol := GroupBy(records, value, agg, col)
+
+	pt := &PivotTable[K, N]{
+		Cells:     make(map[K]map[K]N, len(byRow.Groups)),
+		RowTotals: make(map[K]N, len(byRow.Groups)),
+		ColTotals: make(map[K]N, len(byCol.Groups)),
+		Total:     byRow.Value,
+	}
+	for _, rowGroup := range byRow.Groups {
+		pt.Rows = append(pt.Rows, rowGroup.Key)
+		pt.RowTotals[rowGroup.Key] = rowGroup.Value
+		cells := make(map[K]N, len(rowGroup.Groups))
+		for _, cell := range rowGroup.Groups {
+			cells[cell.Key] = cell.Value
+		}
+		pt.Cells[rowGroup.Key] = cells
+	}
+	for _, colGroup := range byCol.Groups {
+		pt.Cols = append(pt.Cols, colGroup.Key)
+		pt.ColTotals[colGroup.Key] = colGroup.Value
+	}
+	return pt
+}
+
+// Cell returns the aggregate of the row and column, and whether they have records.
+func (pt *PivotTable[K, N]) Cell(row, col K) (N, bool) {
+	value, ok := pt.Cells[row][col]
+	return value, ok
+}
+
+// groupLevel is how the groups at one depth are aggregated.
+type groupLevel[N Number] struct {
+	aggregate AggregatorFunc[N]
+	// rollup aggregates the values of the sub-groups, instead of the values of the records.
+	rollup bool
+}
+
+func groupRecords[R any, K cmp.Ordered, N Number](records []R, value func(R) N, levels []groupLevel[N], keys []func(R) K, key K) *Group[K, N] {
+	group := &Group[K, N]{Key: key, Count: len(records)}
+	if len(keys) > 0 {
+		byKey := map[K][]R{}
+		for _, r := range records {
+			k := keys[0](r)
+			byKey[k] = append(byKey[k], r)
+		}
+		for _, k := range maputil.SortedKeys(byKey) {
+			group.Groups = append(group.Groups, groupRecords(byKey[k], value, levels[1:], keys[1:], k))
+		}
+	}
+
+	var values []N
+	if levels[0].rollup {
+		values = make([]N, len(group.Groups))
+		for i, sub := range group.Groups {
+			values[i] = sub.Value
+		}
+	} else {
+		values = make([]N, len(records))
+		for i, r := range records {
+			values[i] = value(r)
+		}
+	}
+	group.Value = levels[0].aggregate(values...)
+	return group
+}
@@ -0,0 +1,124 @@
+package mathutil_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/toolvox/utilgo/pkg/mathutil"
+)
+
+type sale struct {
+	Region, Product string
+	Amount          int
+}
+
+var sales = []sale{
+	{"north", "apples", 10},
+	{"south", "pears", 4},
+	{"north", "pears", 6},
+	{"south", "apples", 2},
+	{"north", "apples", 5},
+	{"east", "plums", 9},
+}
+
+func saleAmount(s sale) int     { return s.Amount }
+func saleRegion(s sale) string  { return s.Region }
+func saleProduct(s sale) string { return s.Product }
+func summarize(g *mathutil.Group[string, int]) map[string]int {
+	res := map[string]int{}
+	g.Walk(func(path []string, group *mathutil.Group[string, int]) bool {
+		key := "total"
+		for _, k := range path {
+			key += "/" + k
+		}
+		res[key] = group.Value
+		return true
+	})
+	return res
+}
+
+func Test_Grouping(t *testing.T) {
+	t.Run("GroupBy", func(t *testing.T) {
+		must := require.New(t)
+		total := mathutil.GroupBy(sales, saleAmount, mathutil.GetAggregator[int](mathutil.AggSum), saleRegion, saleProduct)
+		must.Equal(map[string]int{
+			"total":              36,
+			"total/east":         9,
+			"total/east/plums":   9,
+			"total/north":        21,
+			"total/north/apples": 15,
+			"total/north/pears":  6,
+			"total/south":        6,
+			"total/south/apples": 2,
+			"total/south/pears":  4,
+		}, summarize(total))
+		must.Equal(6, total.Count)
+		must.Equal([]string{"east", "north", "south"}, []string{total.Groups[0].Key, total.Groups[1].Key, total.Groups[2].Key})
+		must.Equal(2, total.Find("north", "apples").Count)
+		must.Nil(total.Find("north", "plums"))
+		must.Same(total, total.Find())
+
+		var visited int
+		total.Walk(func(path []string, _ *mathutil.Group[string, int]) bool {
+			visited++
+			return len(path) == 0
+		})
+		must.Equal(4, visited)
+
+		flat := mathutil.GroupBy[sale, string](sales, saleAmount, mathutil.GetAggregator[int](mathutil.AggMax))
+		must.Equal(10, flat.Value)
+		must.Empty(flat.Groups)
+
+		empty := mathutil.GroupBy(nil, saleAmount, mathutil.GetAggregator[int](mathutil.AggSum), saleRegion)
+		must.Zero(empty.Value)
+		must.Empty(empty.Groups)
+	})
+
+	t.Run("GroupBySpec", func(t *testing.T) {
+		must := require.New(t)
+		total, err := mathutil.GroupBySpec(sales, saleAmount, mathutil.AggregatorSpec{mathutil.AggAverage, mathutil.AggMax}, saleRegion, saleProduct)
+		must.NoError(err)
+		must.Equal(map[string]int{
+			"total":              6,
+			"total/east":         9,
+			"total/east/plums":   9,
+			"total/north":        8,
+			"total/north/apples": 10,
+			"total/north/pears":  6,
+			"total/south":        3,
+			"total/south/apples": 2,
+			"total/south/pears":  4,
+		}, summarize(total))
+
+		total, err = mathutil.GroupBySpec(sales, saleAmount, mathutil.AggregatorSpec{mathutil.AggMax, mathutil.AggSum, mathutil.AggCount}, saleRegion, saleProduct)
+		must.NoError(err)
+		must.Equal(3, total.Value)
+		must.Equal(2, total.Find("north", "apples").Value)
+
+		total, err = mathutil.GroupBySpec(sales, saleAmount, mathutil.AggregatorSpec{mathutil.AggAverage}, saleRegion)
+		must.NoError(err)
+		must.Equal(6, total.Value)
+
+		_, err = mathutil.GroupBySpec(sales, saleAmount, mathutil.AggregatorSpec{mathutil.AggSum, mathutil.AggSum, mathutil.AggSum}, saleRegion)
+		must.Error(err)
+		_, err = mathutil.GroupBySpec(sales, saleAmount, mathutil.AggregatorSpec{"Nope"}, saleRegion)
+		must.ErrorIs(err, mathutil.ErrUnknownAggregator)
+	})
+
+	t.Run("Pivot", func(t *testing.T) {
+		must := require.New(t)
+		pt := mathutil.Pivot(sales, saleAmount, mathutil.GetAggregator[int](mathutil.AggSum), saleRegion, saleProduct)
+		must.Equal([]string{"east", "north", "south"}, pt.Rows)
+		must.Equal([]string{"apples", "pears", "plums"}, pt.Cols)
+		must.Equal(map[string]int{"east": 9, "north": 21, "south": 6}, pt.RowTotals)
+		must.Equal(map[string]int{"apples": 17, "pears": 10, "plums": 9}, pt.ColTotals)
+		must.Equal(36, pt.Total)
+
+		cell, ok := pt.Cell("north", "apples")
+		must.True(ok)
+		must.Equal(15, cell)
+		_, ok = pt.Cell("east", "apples")
+		must.False(ok)
+	})
+}
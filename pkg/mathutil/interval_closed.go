@@ -1,6 +1,8 @@
 package mathutil
 
 import (
+	"slices"
+
 	"github.com/toolvox/utilgo/api"
 )
 
@@ -17,6 +19,8 @@ func NewClosed[N Number](min, max N) api.Interval[N] {
 	return closedInterval[N]{min, max}
 }
 
+// NewInterval creates an interval between min and max, with each bound either included or excluded.
+// Integer intervals are normalized to closed form, so (1, 5) becomes [2, 4].
 func NewInterval[N Number](min N, includeMin bool, max N, includeMax bool) api.Interval[N] {
 	if max == min && includeMin && includeMax {
 		return NewSingleton(min)
@@ -24,37 +28,30 @@ func NewInterval[N Number](min N, includeMin bool, max N, includeMax bool) api.I
 	if max <= min {
 		return NewEmpty[N]()
 	}
-	switch {
-	case includeMin && includeMax:
+	if includeMin && includeMax {
 		return closedInterval[N]{min, max}
-	case includeMax:
-		return closedInterval[N]{min + 1, max}
-	case includeMin:
-		return closedInterval[N]{min, max - 1}
-	default:
-		return closedInterval[N]{min + 1, max - 1}
 	}
+	if !isInteger[N]() {
+		return openInterval[N]{min, max, !includeMin, !includeMax}
+	}
+
+	p := newPiece(min, boundOf(!includeMin), max, boundOf(!includeMax))
+	return NewClosed(p.min, p.max)
 }
 
 func (ci closedInterval[N]) Min() N { return ci[0] }
 func (ci closedInterval[N]) Max() N { return ci[1] }
-func (ci closedInterval[N]) Len() N { return ci[1] - ci[0] + 1 }
+func (ci closedInterval[N]) Len() N { return addSat(distance(ci[0], ci[1]), 1) }
+
+func (ci closedInterval[N]) MinBound() api.BoundType { return api.BOUND_CLOSED }
+func (ci closedInterval[N]) MaxBound() api.BoundType { return api.BOUND_CLOSED }
 
 func (ci closedInterval[N]) IsEmpty() bool     { return false }
 func (ci closedInterval[N]) IsSingleton() bool { return false }
 func (ci closedInterval[N]) IsCompound() bool  { return false }
+func (ci closedInterval[N]) IsUnbounded() bool { return false }
 
-func (ci closedInterval[N]) Enumerate(step N) []N {
-	if step == 0 {
-		step = 1
-	}
-
-	var res []N
-	for i := ci[0]; i <= ci[1]; i += step {
-		res = append(res, i)
-	}
-	return res
-}
+func (ci closedInterval[N]) Enumerate(step N) []N { return slices.Collect(ci.All(step)) }
 
 func (ci closedInterval[N]) Intervals() []api.Interval[N] { return []api.Interval[N]{ci} }
 
@@ -71,6 +68,9 @@ func (ci closedInterval[N]) Overlaps(other api.Interval[N]) bool {
 	if other == nil || other.IsEmpty() {
 		return false
 	}
+	if !closedForm(other) {
+		return overlapsOf[N](ci, other)
+	}
 	if other.IsSingleton() {
 		return ci.Contains(other.Min())
 	}
@@ -85,7 +85,8 @@ func (ci closedInterval[N]) Overlaps(other api.Interval[N]) bool {
 }
 
 func (ci closedInterval[N]) Equals(other api.Interval[N]) bool {
-	if other == nil || other.IsEmpty() || other.IsSingleton() || other.IsCompound() {
+	if other == nil || other.IsEmpty() || other.IsSingleton() || other.IsCompound() ||
+		other.MinBound() != api.BOUND_CLOSED || other.MaxBound() != api.BOUND_CLOSED {
 		return false
 	}
 
@@ -98,6 +99,9 @@ func (ci closedInterval[N]) Union(other api.Interval[N]) api.Interval[N] {
 		(!other.IsCompound() && ci.Contains(other.Min()) && ci.Contains(other.Max())) {
 		return ci
 	}
+	if !closedForm(other) {
+		return unionOf[N](ci, other)
+	}
 
 	var myMin, myMax N = ci[0], ci[1]
 	subInts := other.Intervals()
@@ -122,6 +126,9 @@ func (ci closedInterval[N]) Intersection(other api.Interval[N]) api.Interval[N]
 	if other == nil || other.IsEmpty() {
 		return other
 	}
+	if !closedForm(other) {
+		return intersectionOf[N](ci, other)
+	}
 	if other.IsSingleton() {
 		if ci.Contains(other.Max()) {
 			return other
@@ -142,6 +149,9 @@ func (ci closedInterval[N]) Difference(other api.Interval[N]) api.Interval[N] {
 	if other == nil || other.IsEmpty() || !ci.Overlaps(other) {
 		return ci
 	}
+	if !closedForm(other) {
+		return differenceOf[N](ci, other)
+	}
 
 	if other.IsSingleton() {
 		if ci[0] == other.Max() || ci[1] == other.Min() {
@@ -179,12 +189,14 @@ func (ci closedInterval[N]) Difference(other api.Interval[N]) api.Interval[N] {
 	return res
 }
 
+func (ci closedInterval[N]) Complement() api.Interval[N] { return complementOf[N](ci) }
+
 func (ci closedInterval[N]) Resize(newSize N, growMode api.GrowFlags) api.Interval[N] {
-	currentSize := ci.Len()
-	minT, maxT := MinValue[N](), MaxValue[N]()
-	growth := newSize - currentSize
+	if newSize <= 0 {
+		return NewEmpty[N]()
+	}
 
-	left, right := Growths[N](growMode, minT, ci.Min(), growth, ci.Max(), maxT)
+	left, right := ResizeEdges(growMode, ci[0], ci[1], ci.Len(), newSize)
 	return NewClosed(left, right)
 }
 
@@ -193,39 +205,22 @@ func (ci closedInterval[N]) Scale(scale float64, growMode api.GrowFlags) api.Int
 		return NewEmpty[N]()
 	}
 
-	newSize := N(float64(ci.Len()) * scale)
+	newSize := scaledSize(ci.Len(), scale)
 	return ci.Resize(newSize, growMode)
 }
 
+// Translate moves the interval by offset.
+// An edge pushed past the bounds of N is pinned to the bound, and the overshoot is taken off the other edge.
 func (ci closedInterval[N]) Translate(offset N, back bool) api.Interval[N] {
-	minT, maxT := MinValue[N](), MaxValue[N]()
-	var newMin, newMax N
-
 	if back {
-		newMin = ci[0] - offset
-		newMax = ci[1] - offset
-		if newMin < minT {
-			difference := minT - newMin
-			newMin = minT
-			if newMax-difference < minT {
-				newMax = minT
-			} else {
-				newMax -= difference
-			}
-		}
-	} else {
-		newMin = ci[0] + offset
-		newMax = ci[1] + offset
-		if newMax > maxT {
-			difference := newMax - maxT
-			newMax = maxT
-			if newMin+difference > maxT {
-				newMin = maxT
-			} else {
-				newMin += difference
-			}
-		}
+		newMin, overshoot := shift(ci[0], offset, true)
+		newMax, _ := shift(ci[1], offset, true)
+		newMax, _ = shift(newMax, overshoot, true)
+		return NewClosed(newMin, newMax)
 	}
 
+	newMax, overshoot := shift(ci[1], offset, false)
+	newMin, _ := shift(ci[0], offset, false)
+	newMin, _ = shift(newMin, overshoot, false)
 	return NewClosed(newMin, newMax)
 }
@@ -20,7 +20,8 @@ func FindCover[T Number](intervals [][2]T) []T {
 	// In-place merge intervals
 	idx := 0
 	for _, interval := range intervals {
-		if MinValue[T]() == interval[0] || interval[0]-1 <= intervals[idx][1] {
+		if MinValue[T]() == interval[0] || interval[0] <= intervals[idx][1] ||
+			(isInteger[T]() && interval[0]-1 <= intervals[idx][1]) {
 			if interval[1] > intervals[idx][1] {
 				intervals[idx][1] = interval[1]
 			}
@@ -54,7 +55,7 @@ func initialGrowths[T Number](gf api.GrowFlags, growth T) (T, T) {
 
 func Growths[T Number](gf api.GrowFlags, leftBound, leftEdge, growth, rightEdge, rightBound T) (T, T) {
 	leftGrow, rightGrow := initialGrowths(gf, growth)
-	leftSlack, rightSlack := leftEdge-leftBound, rightBound-rightEdge
+	leftSlack, rightSlack := distance(leftBound, leftEdge), distance(rightEdge, rightBound)
 	var doOverflow bool = gf&4 == 0
 
 	if !doOverflow {
@@ -101,3 +102,65 @@ func Growths[T Number](gf api.GrowFlags, leftBound, leftEdge, growth, rightEdge,
 
 	return leftEdge, rightEdge
 }
+
+// ResizeEdges moves the edges of the interval [left, right], currently of size currentSize, so it would be of size newSize.
+// Growing follows [Growths] within the bounds of T, shrinking trims the edges using the same split as the growMode.
+func ResizeEdges[T Number](gf api.GrowFlags, left, right, currentSize, newSize T) (T, T) {
+	if newSize >= currentSize {
+		return Growths(gf, MinValue[T](), left, newSize-currentSize, right, MaxValue[T]())
+	}
+	leftShrink, rightShrink := initialGrowths(gf, currentSize-newSize)
+	return left + leftShrink, right - rightShrink
+}
+
+// distance returns hi - lo, saturating at MaxValue when the result does not fit in T.
+// It assumes lo <= hi.
+func distance[T Number](lo, hi T) T {
+	if lo < 0 && hi > MaxValue[T]()+lo {
+		return MaxValue[T]()
+	}
+	return hi - lo
+}
+
+// addSat returns a + b, saturating at MaxValue when the result does not fit in T.
+// It assumes b >= 0.
+func addSat[T Number](a, b T) T {
+	if b > distance(a, MaxValue[T]()) {
+		return MaxValue[T]()
+	}
+	return a + b
+}
+
+// mulSat multiplies non-negative a and b, saturating at MaxValue.
+func mulSat[T Number](a, b T) T {
+	if a != 0 && b > MaxValue[T]()/a {
+		return MaxValue[T]()
+	}
+	return a * b
+}
+
+// shift moves value by offset, towards MinValue when back is set, saturating at the bounds of T.
+// It also returns the part of the offset that overshot the bound.
+func shift[T Number](value, offset T, back bool) (T, T) {
+	if back {
+		slack := distance(MinValue[T](), value)
+		if offset > slack {
+			return MinValue[T](), offset - slack
+		}
+		return value - offset, 0
+	}
+	slack := distance(value, MaxValue[T]())
+	if offset > slack {
+		return MaxValue[T](), offset - slack
+	}
+	return value + offset, 0
+}
+
+// scaledSize returns size * scale, saturating at MaxValue when the result does not fit in T.
+func scaledSize[T Number](size T, scale float64) T {
+	newSize := float64(size) * scale
+	if newSize >= float64(MaxValue[T]()) {
+		return MaxValue[T]()
+	}
+	return T(newSize)
+}
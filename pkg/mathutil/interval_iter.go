@@ -0,0 +1,119 @@
+package mathutil
+
+import (
+	"iter"
+	"slices"
+
+	"github.com/toolvox/utilgo/api"
+)
+
+// contains reports whether value is in p.
+func (p piece[N]) contains(value N) bool {
+	return (p.minBound == api.BOUND_UNBOUNDED || value > p.min || (value == p.min && p.minBound == api.BOUND_CLOSED)) &&
+		(p.maxBound == api.BOUND_UNBOUNDED || value < p.max || (value == p.max && p.maxBound == api.BOUND_CLOSED))
+}
+
+// steps iterates over the values of p, step apart, starting from its min (or its max when back is set).
+//
+// Integers stop before overflowing, and floating-points stop once step is too small to move away from the previous value.
+func (p piece[N]) steps(step N, back bool, yield func(N) bool) bool {
+	if step <= 0 {
+		step = 1
+	}
+	start := p.min
+	if back {
+		start = p.max
+	}
+
+	var prev N
+	for k := 0; ; k++ {
+		var value N
+		switch {
+		case k == 0:
+			value = start
+		case isInteger[N]() && back:
+			if step > distance(MinValue[N](), prev) {
+				return true
+			}
+			value = prev - step
+		case isInteger[N]():
+			if step > distance(prev, MaxValue[N]()) {
+				return true
+			}
+			value = prev + step
+		case back:
+			if value = start - N(k)*step; value >= prev {
+				return true
+			}
+		default:
+			if value = start + N(k)*step; value <= prev {
+				return true
+			}
+		}
+		prev = value
+
+		if !p.contains(value) {
+			// an open edge skips only the first value
+			if k == 0 {
+				continue
+			}
+			return true
+		}
+		if !yield(value) {
+			return false
+		}
+	}
+}
+
+func allOf[N Number](interval api.Interval[N], step N) iter.Seq[N] {
+	return func(yield func(N) bool) {
+		for _, p := range piecesOf(interval) {
+			if !p.steps(step, false, yield) {
+				return
+			}
+		}
+	}
+}
+
+func backwardOf[N Number](interval api.Interval[N], step N) iter.Seq[N] {
+	return func(yield func(N) bool) {
+		pieces := piecesOf(interval)
+		for _, p := range slices.Backward(pieces) {
+			if !p.steps(step, true, yield) {
+				return
+			}
+		}
+	}
+}
+
+func subIntervalsOf[N Number](interval api.Interval[N]) iter.Seq[api.Interval[N]] {
+	return func(yield func(api.Interval[N]) bool) {
+		if interval.IsEmpty() {
+			return
+		}
+		for _, subInt := range interval.Intervals() {
+			if !yield(subInt) {
+				return
+			}
+		}
+	}
+}
+
+func (ni nullInterval[N]) All(_ N) iter.Seq[N]                          { return func(func(N) bool) {} }
+func (ni nullInterval[N]) Backward(_ N) iter.Seq[N]                     { return func(func(N) bool) {} }
+func (ni nullInterval[N]) SubIntervals() iter.Seq[api.Interval[N]]      { return subIntervalsOf[N](ni) }
+func (si singletonInterval[N]) All(step N) iter.Seq[N]                  { return allOf[N](si, step) }
+func (si singletonInterval[N]) Backward(step N) iter.Seq[N]             { return backwardOf[N](si, step) }
+func (si singletonInterval[N]) SubIntervals() iter.Seq[api.Interval[N]] { return subIntervalsOf[N](si) }
+func (ci closedInterval[N]) All(step N) iter.Seq[N]                     { return allOf[N](ci, step) }
+func (ci closedInterval[N]) Backward(step N) iter.Seq[N]                { return backwardOf[N](ci, step) }
+func (ci closedInterval[N]) SubIntervals() iter.Seq[api.Interval[N]]    { return subIntervalsOf[N](ci) }
+func (oi openInterval[N]) All(step N) iter.Seq[N]                       { return allOf[N](oi, step) }
+func (oi openInterval[N]) Backward(step N) iter.Seq[N]                  { return backwardOf[N](oi, step) }
+func (oi openInterval[N]) SubIntervals() iter.Seq[api.Interval[N]]      { return subIntervalsOf[N](oi) }
+func (ui unboundedInterval[N]) All(step N) iter.Seq[N]                  { return allOf[N](ui, step) }
+func (ui unboundedInterval[N]) Backward(step N) iter.Seq[N]             { return backwardOf[N](ui, step) }
+func (ui unboundedInterval[N]) SubIntervals() iter.Seq[api.Interval[N]] { return subIntervalsOf[N](ui) }
+func (mis mergedIntervals[N]) All(step N) iter.Seq[N]                   { return allOf[N](mis, step) }
+func (mis mergedIntervals[N]) Backward(step N) iter.Seq[N]              { return backwardOf[N](mis, step) }
+func (mis mergedIntervals[N]) SubIntervals() iter.Seq[api.Interval[N]]  { return subIntervalsOf[N](mis) }
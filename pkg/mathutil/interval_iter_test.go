@@ -0,0 +1,87 @@
+package mathutil_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/toolvox/utilgo/api"
+	"github.com/toolvox/utilgo/pkg/mathutil"
+)
+
+func Test_Interval_Iter(t *testing.T) {
+	t.Run("All", func(t *testing.T) {
+		tests := []struct {
+			name     string
+			interval api.Interval[int]
+			step     int
+			expected []int
+		}{
+			{"empty", mathutil.NewEmpty[int](), 1, nil},
+			{"singleton", mathutil.NewSingleton(4), 1, []int{4}},
+			{"closed", mathutil.NewClosed(0, 10), 3, []int{0, 3, 6, 9}},
+			{"zero step", mathutil.NewClosed(0, 3), 0, []int{0, 1, 2, 3}},
+			{"merged", merged(0, 2, 10, 12), 1, []int{0, 1, 2, 10, 11, 12}},
+			{"merged step", merged(0, 2, 10, 12), 2, []int{0, 2, 10, 12}},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				require.Equal(t, tt.expected, slices.Collect(tt.interval.All(tt.step)))
+				require.Equal(t, tt.expected, tt.interval.Enumerate(tt.step))
+			})
+		}
+	})
+
+	t.Run("Backward", func(t *testing.T) {
+		require.Equal(t, []int{10, 7, 4, 1}, slices.Collect(mathutil.NewClosed(0, 10).Backward(3)))
+		require.Equal(t, []int{12, 11, 10, 2, 1, 0}, slices.Collect(merged(0, 2, 10, 12).Backward(1)))
+		require.Equal(t, []uint8{5, 3, 1}, slices.Collect(mathutil.NewClosed[uint8](0, 5).Backward(2)))
+		require.Empty(t, slices.Collect(mathutil.NewEmpty[int]().Backward(1)))
+	})
+
+	t.Run("EarlyStop", func(t *testing.T) {
+		var res []int64
+		for v := range mathutil.NewClosed[int64](0, 1<<40).All(1) {
+			if v == 3 {
+				break
+			}
+			res = append(res, v)
+		}
+		require.Equal(t, []int64{0, 1, 2}, res)
+
+		res = nil
+		for v := range mathutil.NewLeftUnbounded[int64](100, true).Backward(10) {
+			if len(res) == 3 {
+				break
+			}
+			res = append(res, v)
+		}
+		require.Equal(t, []int64{100, 90, 80}, res)
+	})
+
+	t.Run("Overflow", func(t *testing.T) {
+		require.Equal(t, []uint8{250, 252, 254}, slices.Collect(mathutil.NewClosed[uint8](250, 255).All(2)))
+		require.Equal(t, []int8{125, 126, 127}, mathutil.NewClosed[int8](125, 127).Enumerate(1))
+		require.Equal(t, []int8{-126, -127, -128}, slices.Collect(mathutil.NewClosed[int8](-128, -126).Backward(1)))
+		require.Equal(t, []int8{120, 123, 126}, slices.Collect(mathutil.NewRightUnbounded[int8](120, true).All(3)))
+	})
+
+	t.Run("Float", func(t *testing.T) {
+		require.Equal(t, []float64{0, 0.25, 0.5, 0.75}, slices.Collect(mathutil.NewInterval(0, true, 1.0, false).All(0.25)))
+		require.Equal(t, []float64{0.25, 0.5, 0.75, 1}, slices.Collect(mathutil.NewInterval(0, false, 1.0, true).All(0.25)))
+		require.Equal(t, []float64{0.75, 0.5, 0.25}, slices.Collect(mathutil.NewInterval(0, false, 1.0, false).Backward(0.25)))
+		require.Equal(t, []float64{1e10}, slices.Collect(mathutil.NewClosed(1e10, 2e10).All(1e-300)))
+		require.Equal(t, []float64{2e10}, slices.Collect(mathutil.NewClosed(1e10, 2e10).Backward(1e-300)))
+	})
+
+	t.Run("SubIntervals", func(t *testing.T) {
+		var res []string
+		for subInt := range merged(0, 2, 5, 5, 10, 12).SubIntervals() {
+			res = append(res, subInt.String())
+		}
+		require.Equal(t, []string{"[0, 2]", "{5}", "[10, 12]"}, res)
+		require.Empty(t, slices.Collect(mathutil.NewEmpty[int]().SubIntervals()))
+		require.Len(t, slices.Collect(mathutil.NewUniversal[int]().SubIntervals()), 1)
+	})
+}
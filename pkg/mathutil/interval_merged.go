@@ -1,6 +1,8 @@
 package mathutil
 
 import (
+	"slices"
+
 	"github.com/toolvox/utilgo/api"
 	"github.com/toolvox/utilgo/pkg/sliceutil"
 )
@@ -12,6 +14,9 @@ func RawMerged[N Number](intervals ...api.Interval[N]) api.Interval[N] {
 	if len(intervals) == 0 {
 		return NewEmpty[N]()
 	}
+	if len(intervals) == 1 && !intervals[0].IsCompound() {
+		return intervals[0]
+	}
 	if len(intervals) == 1 {
 		return NewClosed(intervals[0].Min(), intervals[0].Max())
 	}
@@ -27,23 +32,11 @@ func NewMerged[N Number](intervals ...api.Interval[N]) api.Interval[N] {
 		return intervals[0]
 	}
 
-	rawIntervals := make([][2]N, 0, len(intervals))
-	for i := 0; i < len(intervals); i++ {
-		subInts := intervals[i].Intervals()
-		if subInts == nil {
-			rawIntervals = append(rawIntervals, [2]N{intervals[i].Min(), intervals[i].Max()})
-			continue
-		}
-		for _, subInt := range subInts {
-			rawIntervals = append(rawIntervals, [2]N{subInt.Min(), subInt.Max()})
-		}
+	var pieces []piece[N]
+	for _, interval := range intervals {
+		pieces = append(pieces, piecesOf(interval)...)
 	}
-	rawResult := FindCover(rawIntervals)
-	var res []api.Interval[N] = make([]api.Interval[N], len(rawResult)/2)
-	for i := 0; i < len(res); i++ {
-		res[i] = NewClosed(rawResult[i*2], rawResult[i*2+1])
-	}
-	return mergedIntervals[N](res)
+	return fromPieces(coverPieces(pieces))
 }
 
 func (mis mergedIntervals[N]) Min() N { return mis[0].Min() }
@@ -51,21 +44,28 @@ func (mis mergedIntervals[N]) Max() N { return mis[len(mis)-1].Max() }
 func (mis mergedIntervals[N]) Len() N {
 	var result N
 	for _, v := range mis.Intervals() {
-		result += v.Len()
+		result = addSat(result, v.Len())
 	}
 	return result
 }
 
+func (mis mergedIntervals[N]) MinBound() api.BoundType { return mis[0].MinBound() }
+func (mis mergedIntervals[N]) MaxBound() api.BoundType { return mis[len(mis)-1].MaxBound() }
+
 func (mis mergedIntervals[N]) IsEmpty() bool     { return false }
 func (mis mergedIntervals[N]) IsSingleton() bool { return false }
 func (mis mergedIntervals[N]) IsCompound() bool  { return true }
+func (mis mergedIntervals[N]) IsUnbounded() bool {
+	return mis[0].IsUnbounded() || mis[len(mis)-1].IsUnbounded()
+}
 
+// Enumerate returns nil when the intervals are unbounded, as there is no end to enumerate to.
 func (mis mergedIntervals[N]) Enumerate(step N) []N {
-	var res []N
-	for _, subInt := range mis {
-		res = append(res, subInt.Enumerate(step)...)
+	if mis.IsUnbounded() {
+		return nil
 	}
-	return res
+
+	return slices.Collect(mis.All(step))
 }
 
 func (mis mergedIntervals[N]) Intervals() []api.Interval[N] {
@@ -116,6 +116,9 @@ func (mis mergedIntervals[N]) Union(other api.Interval[N]) api.Interval[N] {
 	if other == nil || other.IsEmpty() {
 		return mis
 	}
+	if !closedForm(mis, other) {
+		return unionOf[N](mis, other)
+	}
 	if !other.IsCompound() {
 		return other.Union(mis)
 	}
@@ -127,9 +130,12 @@ func (mis mergedIntervals[N]) Intersection(other api.Interval[N]) api.Interval[N
 	if other == nil || other.IsEmpty() {
 		return NewEmpty[N]()
 	}
+	if !closedForm(mis, other) {
+		return intersectionOf[N](mis, other)
+	}
 	var res []api.Interval[N]
 	for _, subInt := range mis {
-		res = append(res, other.Intersection(subInt))
+		res = append(res, other.Intersection(subInt).Intervals()...)
 	}
 	return RawMerged(res...)
 }
@@ -138,6 +144,9 @@ func (mis mergedIntervals[N]) Difference(other api.Interval[N]) api.Interval[N]
 	if other == nil || other.IsEmpty() || !other.Overlaps(mis) {
 		return mis
 	}
+	if !closedForm(mis, other) {
+		return differenceOf[N](mis, other)
+	}
 
 	var res []api.Interval[N]
 	if !other.IsCompound() {
@@ -151,17 +160,60 @@ func (mis mergedIntervals[N]) Difference(other api.Interval[N]) api.Interval[N]
 		for _, otherSubInt := range otherIntervals {
 			subInt = subInt.Difference(otherSubInt)
 		}
-		res = append(res, subInt)
+		res = append(res, subInt.Intervals()...)
 	}
 	return RawMerged(res...)
 }
 
+func (mis mergedIntervals[N]) Complement() api.Interval[N] { return complementOf[N](mis) }
+
+// Resize resizes the hull of the intervals to newSize, preserving the gaps between them.
+// Unbounded intervals have no finite hull, and are returned unchanged.
 func (mis mergedIntervals[N]) Resize(newSize N, growMode api.GrowFlags) api.Interval[N] {
-	panic("#TODO: not implemented")
+	if mis.IsUnbounded() {
+		return mis
+	}
+	if newSize <= 0 {
+		return NewEmpty[N]()
+	}
+
+	// Growing extends the outermost intervals, shrinking trims everything outside of the resized hull.
+	hull := closedInterval[N]{mis.Min(), mis.Max()}
+	left, right := ResizeEdges(growMode, hull[0], hull[1], hull.Len(), newSize)
+	if newSize < hull.Len() {
+		return mis.Intersection(NewClosed(left, right))
+	}
+
+	res := slices.Clone(mis)
+	res[0] = withEdges(res[0], left, res[0].Max())
+	res[len(res)-1] = withEdges(res[len(res)-1], res[len(res)-1].Min(), right)
+	return res
 }
+
+// Scale scales the hull of the intervals by scale, preserving the gaps between them.
 func (mis mergedIntervals[N]) Scale(scale float64, growMode api.GrowFlags) api.Interval[N] {
-	panic("#TODO: not implemented")
+	if mis.IsUnbounded() {
+		return mis
+	}
+	if scale <= 0 {
+		return NewEmpty[N]()
+	}
+
+	newSize := scaledSize(closedInterval[N]{mis.Min(), mis.Max()}.Len(), scale)
+	return mis.Resize(newSize, growMode)
 }
+
+// Translate moves every interval by offset.
+// Intervals pushed against the bounds of N saturate like [closedInterval.Translate], and merge if they meet.
 func (mis mergedIntervals[N]) Translate(offset N, back bool) api.Interval[N] {
-	panic("#TODO: not implemented")
+	var saturated bool
+	res := make([]api.Interval[N], len(mis))
+	for i, subInt := range mis {
+		res[i] = subInt.Translate(offset, back)
+		saturated = saturated || (i > 0 && res[i].Min() <= res[i-1].Max())
+	}
+	if saturated {
+		return NewMerged(res...)
+	}
+	return RawMerged(res...)
 }
@@ -14,9 +14,13 @@ func (ni nullInterval[N]) Min() N { return N(0) }
 func (ni nullInterval[N]) Max() N { return N(0) }
 func (ni nullInterval[N]) Len() N { return N(0) }
 
+func (ni nullInterval[N]) MinBound() api.BoundType { return api.BOUND_CLOSED }
+func (ni nullInterval[N]) MaxBound() api.BoundType { return api.BOUND_CLOSED }
+
 func (ni nullInterval[N]) IsEmpty() bool     { return true }
 func (ni nullInterval[N]) IsSingleton() bool { return false }
 func (ni nullInterval[N]) IsCompound() bool  { return false }
+func (ni nullInterval[N]) IsUnbounded() bool { return false }
 
 func (ni nullInterval[N]) Enumerate(_ N) []N            { return nil }
 func (ni nullInterval[N]) Intervals() []api.Interval[N] { return nil }
@@ -30,6 +34,7 @@ func (ni nullInterval[N]) Equals(other api.Interval[N]) bool {
 func (ni nullInterval[N]) Union(other api.Interval[N]) api.Interval[N]    { return other }
 func (ni nullInterval[N]) Intersection(_ api.Interval[N]) api.Interval[N] { return ni }
 func (ni nullInterval[N]) Difference(_ api.Interval[N]) api.Interval[N]   { return ni }
+func (ni nullInterval[N]) Complement() api.Interval[N]                    { return NewUniversal[N]() }
 
 func (ni nullInterval[N]) Resize(_ N, _ api.GrowFlags) api.Interval[N]      { return ni }
 func (ni nullInterval[N]) Scale(_ float64, _ api.GrowFlags) api.Interval[N] { return ni }
@@ -0,0 +1,94 @@
+package mathutil
+
+import (
+	"slices"
+
+	"github.com/toolvox/utilgo/api"
+)
+
+// openInterval is an interval of floating-point values with at least one open bound.
+// Integer intervals are always normalized to closed form instead.
+type openInterval[N Number] struct {
+	min, max         N
+	minOpen, maxOpen bool
+}
+
+func (oi openInterval[N]) Min() N { return oi.min }
+func (oi openInterval[N]) Max() N { return oi.max }
+
+// Len mirrors closedInterval.Len so sizes stay comparable between the two.
+func (oi openInterval[N]) Len() N { return addSat(distance(oi.min, oi.max), 1) }
+
+func (oi openInterval[N]) MinBound() api.BoundType { return boundOf(oi.minOpen) }
+func (oi openInterval[N]) MaxBound() api.BoundType { return boundOf(oi.maxOpen) }
+
+func (oi openInterval[N]) IsEmpty() bool     { return false }
+func (oi openInterval[N]) IsSingleton() bool { return false }
+func (oi openInterval[N]) IsCompound() bool  { return false }
+func (oi openInterval[N]) IsUnbounded() bool { return false }
+
+func (oi openInterval[N]) Enumerate(step N) []N { return slices.Collect(oi.All(step)) }
+
+func (oi openInterval[N]) Intervals() []api.Interval[N] { return []api.Interval[N]{oi} }
+
+func (oi openInterval[N]) Contains(value N) bool {
+	return (value > oi.min || (value == oi.min && !oi.minOpen)) &&
+		(value < oi.max || (value == oi.max && !oi.maxOpen))
+}
+
+func (oi openInterval[N]) Overlaps(other api.Interval[N]) bool { return overlapsOf[N](oi, other) }
+
+func (oi openInterval[N]) Equals(other api.Interval[N]) bool {
+	if other == nil || other.IsEmpty() || other.IsSingleton() || other.IsCompound() {
+		return false
+	}
+
+	return oi.min == other.Min() && oi.max == other.Max() &&
+		oi.MinBound() == other.MinBound() && oi.MaxBound() == other.MaxBound()
+}
+
+func (oi openInterval[N]) Union(other api.Interval[N]) api.Interval[N] {
+	return unionOf[N](oi, other)
+}
+
+func (oi openInterval[N]) Intersection(other api.Interval[N]) api.Interval[N] {
+	return intersectionOf[N](oi, other)
+}
+
+func (oi openInterval[N]) Difference(other api.Interval[N]) api.Interval[N] {
+	return differenceOf[N](oi, other)
+}
+
+func (oi openInterval[N]) Complement() api.Interval[N] { return complementOf[N](oi) }
+
+func (oi openInterval[N]) Resize(newSize N, growMode api.GrowFlags) api.Interval[N] {
+	return oi.withBounds(closedInterval[N]{oi.min, oi.max}.Resize(newSize, growMode))
+}
+
+func (oi openInterval[N]) Scale(scale float64, growMode api.GrowFlags) api.Interval[N] {
+	return oi.withBounds(closedInterval[N]{oi.min, oi.max}.Scale(scale, growMode))
+}
+
+func (oi openInterval[N]) Translate(offset N, back bool) api.Interval[N] {
+	return oi.withBounds(closedInterval[N]{oi.min, oi.max}.Translate(offset, back))
+}
+
+// withBounds applies the bounds of oi to the edges of the simple interval res.
+func (oi openInterval[N]) withBounds(res api.Interval[N]) api.Interval[N] {
+	if res.IsEmpty() {
+		return res
+	}
+	return NewInterval(res.Min(), !oi.minOpen, res.Max(), !oi.maxOpen)
+}
+
+// withEdges returns a simple interval with the given edges and the bounds of interval.
+func withEdges[N Number](interval api.Interval[N], min, max N) api.Interval[N] {
+	return NewInterval(min, interval.MinBound() == api.BOUND_CLOSED, max, interval.MaxBound() == api.BOUND_CLOSED)
+}
+
+func boundOf(open bool) api.BoundType {
+	if open {
+		return api.BOUND_OPEN
+	}
+	return api.BOUND_CLOSED
+}
@@ -0,0 +1,239 @@
+package mathutil
+
+import (
+	"slices"
+
+	"github.com/toolvox/utilgo/api"
+)
+
+// piece is a simple interval with explicit bounds, used for bound-aware set operations.
+// Integer pieces are never open, and unbounded edges hold the matching MinValue or MaxValue.
+type piece[N Number] struct {
+	min, max           N
+	minBound, maxBound api.BoundType
+}
+
+func newPiece[N Number](min N, minBound api.BoundType, max N, maxBound api.BoundType) piece[N] {
+	if minBound == api.BOUND_UNBOUNDED {
+		min = MinValue[N]()
+	}
+	if maxBound == api.BOUND_UNBOUNDED {
+		max = MaxValue[N]()
+	}
+	if isInteger[N]() {
+		if (minBound == api.BOUND_OPEN && min == MaxValue[N]()) || (maxBound == api.BOUND_OPEN && max == MinValue[N]()) {
+			return piece[N]{min: 1}
+		}
+		if minBound == api.BOUND_OPEN {
+			min, minBound = min+1, api.BOUND_CLOSED
+		}
+		if maxBound == api.BOUND_OPEN {
+			max, maxBound = max-1, api.BOUND_CLOSED
+		}
+	}
+	return piece[N]{min, max, minBound, maxBound}
+}
+
+func (p piece[N]) isEmpty() bool {
+	return p.max < p.min || (p.max == p.min && (p.minBound == api.BOUND_OPEN || p.maxBound == api.BOUND_OPEN))
+}
+
+func (p piece[N]) interval() api.Interval[N] {
+	if p.minBound == api.BOUND_UNBOUNDED || p.maxBound == api.BOUND_UNBOUNDED {
+		return unboundedInterval[N]{p.min, p.max, p.minBound, p.maxBound}
+	}
+	return NewInterval(p.min, p.minBound == api.BOUND_CLOSED, p.max, p.maxBound == api.BOUND_CLOSED)
+}
+
+// edgeRank orders bounds sharing the same edge value, from the one reaching furthest out to the one reaching the least.
+func edgeRank(bound api.BoundType) int {
+	switch bound {
+	case api.BOUND_UNBOUNDED:
+		return 0
+	case api.BOUND_CLOSED:
+		return 1
+	default:
+		return 2
+	}
+}
+
+// startsBefore reports whether p starts before other.
+func (p piece[N]) startsBefore(other piece[N]) bool {
+	return p.min < other.min || (p.min == other.min && edgeRank(p.minBound) < edgeRank(other.minBound))
+}
+
+// endsAfter reports whether p ends after other.
+func (p piece[N]) endsAfter(other piece[N]) bool {
+	return p.max > other.max || (p.max == other.max && edgeRank(p.maxBound) < edgeRank(other.maxBound))
+}
+
+// intersect returns the common part of p and other, which may be empty.
+func (p piece[N]) intersect(other piece[N]) piece[N] {
+	lo, hi := p, other
+	if p.startsBefore(other) {
+		lo = other
+	}
+	if other.endsAfter(p) {
+		hi = p
+	}
+	return piece[N]{lo.min, hi.max, lo.minBound, hi.maxBound}
+}
+
+// joins reports whether next, which does not start before p, can be merged into p without a gap.
+func (p piece[N]) joins(next piece[N]) bool {
+	if next.min < p.max {
+		return true
+	}
+	if next.min == p.max {
+		return next.minBound != api.BOUND_OPEN || p.maxBound != api.BOUND_OPEN
+	}
+	return isInteger[N]() && p.max != MaxValue[N]() && next.min == p.max+1
+}
+
+func piecesOf[N Number](interval api.Interval[N]) []piece[N] {
+	if interval == nil || interval.IsEmpty() {
+		return nil
+	}
+	subInts := interval.Intervals()
+	res := make([]piece[N], 0, len(subInts))
+	for _, subInt := range subInts {
+		res = append(res, newPiece(subInt.Min(), subInt.MinBound(), subInt.Max(), subInt.MaxBound()))
+	}
+	return res
+}
+
+func fromPieces[N Number](pieces []piece[N]) api.Interval[N] {
+	res := make([]api.Interval[N], 0, len(pieces))
+	for _, p := range pieces {
+		if p.isEmpty() {
+			continue
+		}
+		res = append(res, p.interval())
+	}
+	switch len(res) {
+	case 0:
+		return NewEmpty[N]()
+	case 1:
+		return res[0]
+	default:
+		return mergedIntervals[N](res)
+	}
+}
+
+// coverPieces sorts the pieces and merges the overlapping or touching ones.
+func coverPieces[N Number](pieces []piece[N]) []piece[N] {
+	pieces = slices.DeleteFunc(slices.Clone(pieces), piece[N].isEmpty)
+	if len(pieces) == 0 {
+		return nil
+	}
+	slices.SortStableFunc(pieces, func(a, b piece[N]) int {
+		switch {
+		case a.startsBefore(b):
+			return -1
+		case b.startsBefore(a):
+			return 1
+		default:
+			return 0
+		}
+	})
+
+	idx := 0
+	for _, p := range pieces[1:] {
+		if !pieces[idx].joins(p) {
+			idx++
+			pieces[idx] = p
+			continue
+		}
+		if p.endsAfter(pieces[idx]) {
+			pieces[idx].max, pieces[idx].maxBound = p.max, p.maxBound
+		}
+	}
+	return pieces[:idx+1]
+}
+
+// intersectPieces intersects two covers, as returned by coverPieces.
+func intersectPieces[N Number](left, right []piece[N]) []piece[N] {
+	var res []piece[N]
+	for li, ri := 0, 0; li < len(left) && ri < len(right); {
+		l, r := left[li], right[ri]
+		if p := l.intersect(r); !p.isEmpty() {
+			res = append(res, p)
+		}
+		if r.endsAfter(l) {
+			li++
+		} else {
+			ri++
+		}
+	}
+	return res
+}
+
+// subtractPieces removes the cover right from the cover left.
+func subtractPieces[N Number](left, right []piece[N]) []piece[N] {
+	var res []piece[N]
+	for _, cur := range left {
+		for _, r := range right {
+			if cur.isEmpty() {
+				break
+			}
+			if cur.intersect(r).isEmpty() {
+				continue
+			}
+			if r.minBound != api.BOUND_UNBOUNDED {
+				if before := newPiece(cur.min, cur.minBound, r.min, flipBound(r.minBound)); !before.isEmpty() {
+					res = append(res, before)
+				}
+			}
+			if r.maxBound == api.BOUND_UNBOUNDED {
+				cur = piece[N]{min: 1}
+				break
+			}
+			cur = newPiece(r.max, flipBound(r.maxBound), cur.max, cur.maxBound)
+		}
+		if !cur.isEmpty() {
+			res = append(res, cur)
+		}
+	}
+	return res
+}
+
+// flipBound returns the bound of the edge on the other side of a closed or open edge.
+func flipBound(bound api.BoundType) api.BoundType {
+	if bound == api.BOUND_OPEN {
+		return api.BOUND_CLOSED
+	}
+	return api.BOUND_OPEN
+}
+
+// closedForm reports whether the intervals can all use the closed integer fast paths.
+func closedForm[N Number](intervals ...api.Interval[N]) bool {
+	if !isInteger[N]() {
+		return false
+	}
+	for _, interval := range intervals {
+		if interval != nil && interval.IsUnbounded() {
+			return false
+		}
+	}
+	return true
+}
+
+func unionOf[N Number](left, right api.Interval[N]) api.Interval[N] {
+	return fromPieces(coverPieces(append(piecesOf(left), piecesOf(right)...)))
+}
+
+func intersectionOf[N Number](left, right api.Interval[N]) api.Interval[N] {
+	return fromPieces(intersectPieces(coverPieces(piecesOf(left)), coverPieces(piecesOf(right))))
+}
+
+func differenceOf[N Number](left, right api.Interval[N]) api.Interval[N] {
+	return fromPieces(subtractPieces(coverPieces(piecesOf(left)), coverPieces(piecesOf(right))))
+}
+
+func complementOf[N Number](interval api.Interval[N]) api.Interval[N] {
+	return differenceOf(NewUniversal[N](), interval)
+}
+
+func overlapsOf[N Number](left, right api.Interval[N]) bool {
+	return len(intersectPieces(coverPieces(piecesOf(left)), coverPieces(piecesOf(right)))) > 0
+}
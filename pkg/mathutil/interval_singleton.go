@@ -14,9 +14,13 @@ func (si singletonInterval[N]) Min() N { return si[0] }
 func (si singletonInterval[N]) Max() N { return si[0] }
 func (si singletonInterval[N]) Len() N { return 1 }
 
+func (si singletonInterval[N]) MinBound() api.BoundType { return api.BOUND_CLOSED }
+func (si singletonInterval[N]) MaxBound() api.BoundType { return api.BOUND_CLOSED }
+
 func (si singletonInterval[N]) IsEmpty() bool     { return false }
 func (si singletonInterval[N]) IsSingleton() bool { return true }
 func (si singletonInterval[N]) IsCompound() bool  { return false }
+func (si singletonInterval[N]) IsUnbounded() bool { return false }
 
 func (si singletonInterval[N]) Enumerate(_ N) []N            { return []N{si[0]} }
 func (si singletonInterval[N]) Intervals() []api.Interval[N] { return []api.Interval[N]{si} }
@@ -36,6 +40,9 @@ func (si singletonInterval[N]) Union(other api.Interval[N]) api.Interval[N] {
 	if other.Contains(si[0]) {
 		return other
 	}
+	if !closedForm(other) {
+		return unionOf[N](si, other)
+	}
 	otherInts := other.Intervals()
 	var res []api.Interval[N]
 	var firstAfter int
@@ -66,6 +73,8 @@ func (si singletonInterval[N]) Difference(other api.Interval[N]) api.Interval[N]
 	return NewEmpty[N]()
 }
 
+func (si singletonInterval[N]) Complement() api.Interval[N] { return complementOf[N](si) }
+
 func (si singletonInterval[N]) Resize(newSize N, growMode api.GrowFlags) api.Interval[N] {
 	if newSize <= 1 {
 		return si
@@ -78,23 +87,11 @@ func (si singletonInterval[N]) Resize(newSize N, growMode api.GrowFlags) api.Int
 }
 
 func (si singletonInterval[N]) Scale(scale float64, growMode api.GrowFlags) api.Interval[N] {
-	newSize := N(scale * float64(si.Len()))
+	newSize := scaledSize(si.Len(), scale)
 	return si.Resize(newSize, growMode)
 }
 
 func (si singletonInterval[N]) Translate(offset N, back bool) api.Interval[N] {
-	minT := MinValue[N]()
-	if back {
-		leftSlack := si[0] - minT
-		if offset >= leftSlack {
-			return NewSingleton(minT)
-		}
-		return NewSingleton(si[0] - offset)
-	}
-	maxT := MaxValue[N]()
-	rightSlack := maxT - si[0]
-	if offset >= rightSlack {
-		return NewSingleton(maxT)
-	}
-	return NewSingleton(si[0] + offset)
+	newValue, _ := shift(si[0], offset, back)
+	return NewSingleton(newValue)
 }
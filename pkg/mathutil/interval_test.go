@@ -0,0 +1,279 @@
+package mathutil_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/toolvox/utilgo/api"
+	"github.com/toolvox/utilgo/pkg/mathutil"
+)
+
+func requireInterval[N mathutil.Number](t *testing.T, expected, actual api.Interval[N]) {
+	t.Helper()
+	require.True(t, expected.Equals(actual), "expected %s, got %s", expected, actual)
+}
+
+func merged[N mathutil.Number](bounds ...N) api.Interval[N] {
+	var res []api.Interval[N]
+	for i := 0; i < len(bounds); i += 2 {
+		res = append(res, mathutil.NewClosed(bounds[i], bounds[i+1]))
+	}
+	return mathutil.RawMerged(res...)
+}
+
+func Run_Test_Interval_Merged[N mathutil.Number]() func(*testing.T) {
+	return func(t *testing.T) {
+		// hull is [10, 49], of size 40
+		testInterval := merged[N](10, 20, 30, 49)
+
+		t.Run("Resize", func(t *testing.T) {
+			tests := []struct {
+				newSize  N
+				growMode api.GrowFlags
+				expected api.Interval[N]
+			}{
+				{50, api.GROW_BOTH_OVERFLOW_RIGHT, merged[N](5, 20, 30, 54)},
+				{50, api.GROW_LEFT_OVERFLOW_RIGHT, merged[N](0, 20, 30, 49)},
+				{50, api.GROW_RIGHT_OVERFLOW_LEFT, merged[N](10, 20, 30, 59)},
+				{50, api.GROW_BOTH_OVERFLOW_LEFT, merged[N](5, 20, 30, 54)},
+				{50, api.GROW_NO_OVERFLOW, merged[N](5, 20, 30, 54)},
+				{40, api.GROW_BOTH_OVERFLOW_RIGHT, testInterval},
+				{30, api.GROW_BOTH_OVERFLOW_RIGHT, merged[N](15, 20, 30, 44)},
+				{30, api.GROW_LEFT_OVERFLOW_RIGHT, mathutil.RawMerged(mathutil.NewSingleton[N](20), mathutil.NewClosed[N](30, 49))},
+				{30, api.GROW_RIGHT_OVERFLOW_LEFT, merged[N](10, 20, 30, 39)},
+				{5, api.GROW_RIGHT_OVERFLOW_LEFT, mathutil.NewClosed[N](10, 14)},
+				{0, api.GROW_BOTH_OVERFLOW_RIGHT, mathutil.NewEmpty[N]()},
+			}
+			for ti, tt := range tests {
+				t.Run(fmt.Sprint(ti), func(t *testing.T) {
+					requireInterval(t, tt.expected, testInterval.Resize(tt.newSize, tt.growMode))
+				})
+			}
+		})
+
+		t.Run("Scale", func(t *testing.T) {
+			tests := []struct {
+				scale    float64
+				growMode api.GrowFlags
+				expected api.Interval[N]
+			}{
+				{1, api.GROW_BOTH_OVERFLOW_RIGHT, testInterval},
+				{1.5, api.GROW_RIGHT_OVERFLOW_LEFT, merged[N](10, 20, 30, 69)},
+				{0.5, api.GROW_BOTH_OVERFLOW_RIGHT, mathutil.RawMerged(mathutil.NewSingleton[N](20), mathutil.NewClosed[N](30, 39))},
+				{0, api.GROW_BOTH_OVERFLOW_RIGHT, mathutil.NewEmpty[N]()},
+			}
+			for ti, tt := range tests {
+				t.Run(fmt.Sprint(ti), func(t *testing.T) {
+					requireInterval(t, tt.expected, testInterval.Scale(tt.scale, tt.growMode))
+				})
+			}
+		})
+
+		t.Run("Translate", func(t *testing.T) {
+			requireInterval(t, merged[N](15, 25, 35, 54), testInterval.Translate(5, false))
+			requireInterval(t, merged[N](5, 15, 25, 44), testInterval.Translate(5, true))
+			requireInterval(t, testInterval, testInterval.Translate(0, false))
+		})
+	}
+}
+
+func Test_Interval_Merged(t *testing.T) {
+	t.Run("int", Run_Test_Interval_Merged[int]())
+	t.Run("int8", Run_Test_Interval_Merged[int8]())
+	t.Run("int64", Run_Test_Interval_Merged[int64]())
+	t.Run("uint", Run_Test_Interval_Merged[uint]())
+	t.Run("uint8", Run_Test_Interval_Merged[uint8]())
+	t.Run("float32", Run_Test_Interval_Merged[float32]())
+	t.Run("float64", Run_Test_Interval_Merged[float64]())
+
+	t.Run("OddGrowth", func(t *testing.T) {
+		testInterval := merged(10, 20, 30, 49)
+		requireInterval(t, merged(5, 20, 30, 55), testInterval.Resize(51, api.GROW_BOTH_OVERFLOW_RIGHT))
+		requireInterval(t, merged(4, 20, 30, 54), testInterval.Resize(51, api.GROW_BOTH_OVERFLOW_LEFT))
+	})
+
+	t.Run("Overflow", func(t *testing.T) {
+		testInterval := merged[uint8](10, 20, 30, 49)
+		requireInterval(t, merged[uint8](0, 20, 30, 69), testInterval.Resize(70, api.GROW_LEFT_OVERFLOW_RIGHT))
+		requireInterval(t, merged[uint8](0, 20, 30, 49), testInterval.Resize(70, api.GROW_NO_OVERFLOW|api.GROW_LEFT_OVERFLOW_RIGHT))
+		requireInterval(t, merged[uint8](0, 20, 30, 254), testInterval.Scale(10, api.GROW_BOTH_OVERFLOW_RIGHT))
+	})
+
+	t.Run("TranslateSaturation", func(t *testing.T) {
+		testInterval := merged[uint8](200, 210, 240, 250)
+		requireInterval(t, mathutil.RawMerged(mathutil.NewClosed[uint8](210, 220), mathutil.NewSingleton[uint8](255)), testInterval.Translate(10, false))
+		requireInterval(t, mathutil.NewSingleton[uint8](255), testInterval.Translate(100, false))
+
+		signedInterval := merged[int8](-120, -110, -100, -90)
+		requireInterval(t, mathutil.RawMerged(mathutil.NewSingleton[int8](-128), mathutil.NewClosed[int8](-120, -110)), signedInterval.Translate(20, true))
+	})
+}
+
+func Test_Interval_Closed_Signed(t *testing.T) {
+	requireInterval(t, mathutil.NewClosed(-5, 15), mathutil.NewClosed(0, 10).Resize(21, api.GROW_BOTH_OVERFLOW_RIGHT))
+	requireInterval(t, mathutil.NewClosed(5, 15), mathutil.NewClosed(0, 10).Translate(5, false))
+	requireInterval(t, mathutil.NewSingleton(-5), mathutil.NewSingleton(0).Translate(5, true))
+}
+
+func Run_Test_Interval_Open[N api.FloatingPoint]() func(*testing.T) {
+	return func(t *testing.T) {
+		halfOpen := mathutil.NewInterval[N](0, true, 1, false)
+		open := mathutil.NewInterval[N](1, false, 2, false)
+
+		t.Run("Bounds", func(t *testing.T) {
+			must := require.New(t)
+			must.Equal(api.BOUND_CLOSED, halfOpen.MinBound())
+			must.Equal(api.BOUND_OPEN, halfOpen.MaxBound())
+			must.False(halfOpen.Equals(mathutil.NewClosed[N](0, 1)))
+			must.False(mathutil.NewClosed[N](0, 1).Equals(halfOpen))
+			must.True(halfOpen.Equals(mathutil.NewInterval[N](0, true, 1, false)))
+		})
+
+		t.Run("Contains", func(t *testing.T) {
+			must := require.New(t)
+			must.True(halfOpen.Contains(0))
+			must.True(halfOpen.Contains(0.5))
+			must.False(halfOpen.Contains(1))
+			must.False(open.Contains(1))
+			must.False(open.Contains(2))
+			must.True(open.Contains(1.5))
+		})
+
+		t.Run("Overlaps", func(t *testing.T) {
+			must := require.New(t)
+			must.False(halfOpen.Overlaps(open))
+			must.False(halfOpen.Overlaps(mathutil.NewClosed[N](1, 2)))
+			must.False(mathutil.NewClosed[N](1, 2).Overlaps(halfOpen))
+			must.False(mathutil.NewSingleton[N](1).Overlaps(halfOpen))
+			must.True(halfOpen.Overlaps(mathutil.NewClosed[N](0.5, 2)))
+			must.True(open.Overlaps(mathutil.NewSingleton[N](1.5)))
+		})
+
+		t.Run("Union", func(t *testing.T) {
+			requireInterval(t, mathutil.NewClosed[N](0, 2), halfOpen.Union(mathutil.NewClosed[N](1, 2)))
+			requireInterval(t, mathutil.NewClosed[N](0, 1), halfOpen.Union(mathutil.NewSingleton[N](1)))
+			requireInterval(t, mathutil.NewClosed[N](0, 1), mathutil.NewSingleton[N](1).Union(halfOpen))
+			requireInterval(t, mathutil.RawMerged(halfOpen, open), halfOpen.Union(open))
+			requireInterval(t, mathutil.NewInterval[N](0, true, 2, false), mathutil.NewMerged(halfOpen, mathutil.NewSingleton[N](1), open))
+			requireInterval(t, mathutil.RawMerged(mathutil.NewClosed[N](0, 0.5), mathutil.NewClosed[N](0.75, 1)),
+				mathutil.NewClosed[N](0, 0.5).Union(mathutil.NewClosed[N](0.75, 1)))
+		})
+
+		t.Run("Intersection", func(t *testing.T) {
+			requireInterval(t, mathutil.NewEmpty[N](), halfOpen.Intersection(open))
+			requireInterval(t, mathutil.NewInterval[N](0.5, true, 1, false), halfOpen.Intersection(mathutil.NewClosed[N](0.5, 2)))
+			requireInterval(t, mathutil.NewInterval[N](0.5, true, 1, false), mathutil.NewClosed[N](0.5, 2).Intersection(halfOpen))
+		})
+
+		t.Run("Difference", func(t *testing.T) {
+			requireInterval(t, mathutil.RawMerged(halfOpen, mathutil.NewInterval[N](1, false, 2, true)), mathutil.NewClosed[N](0, 2).Difference(mathutil.NewSingleton[N](1)))
+			requireInterval(t, mathutil.NewClosed[N](1, 2), mathutil.NewClosed[N](0, 2).Difference(halfOpen))
+			requireInterval(t, mathutil.RawMerged(mathutil.NewClosed[N](0, 1), mathutil.NewSingleton[N](2)), mathutil.NewClosed[N](0, 2).Difference(open))
+			requireInterval(t, mathutil.NewSingleton[N](0), halfOpen.Difference(mathutil.NewInterval[N](0, false, 1, true)))
+		})
+
+		t.Run("FindCover", func(t *testing.T) {
+			require.Equal(t, []N{0, 0.5, 1, 2}, mathutil.FindCover([][2]N{{1, 2}, {0, 0.5}}))
+			require.Equal(t, []N{0, 2}, mathutil.FindCover([][2]N{{1, 2}, {0, 1}}))
+		})
+	}
+}
+
+func Test_Interval_Open(t *testing.T) {
+	t.Run("float32", Run_Test_Interval_Open[float32]())
+	t.Run("float64", Run_Test_Interval_Open[float64]())
+
+	t.Run("IntegerNormalization", func(t *testing.T) {
+		requireInterval(t, mathutil.NewClosed(2, 4), mathutil.NewInterval(1, false, 5, false))
+		requireInterval(t, mathutil.NewClosed(1, 4), mathutil.NewInterval(1, true, 5, false))
+		requireInterval(t, mathutil.NewEmpty[int](), mathutil.NewInterval(1, false, 2, false))
+		requireInterval(t, mathutil.NewEmpty[uint8](), mathutil.NewInterval[uint8](254, false, 255, false))
+	})
+}
+
+func Run_Test_Interval_Unbounded[N mathutil.Number]() func(*testing.T) {
+	return func(t *testing.T) {
+		universal := mathutil.NewUniversal[N]()
+		atLeast := mathutil.NewRightUnbounded[N](10, true)
+		atMost := mathutil.NewLeftUnbounded[N](20, true)
+
+		t.Run("Len", func(t *testing.T) {
+			must := require.New(t)
+			for _, interval := range []api.Interval[N]{universal, atLeast, atMost, mathutil.RawMerged(atMost.Complement(), atLeast.Complement())} {
+				must.True(interval.IsUnbounded())
+				must.Equal(mathutil.MaxValue[N](), interval.Len())
+				must.Nil(interval.Enumerate(1))
+			}
+			must.False(mathutil.NewClosed[N](10, 20).IsUnbounded())
+		})
+
+		t.Run("Bounds", func(t *testing.T) {
+			must := require.New(t)
+			must.Equal(api.BOUND_UNBOUNDED, universal.MinBound())
+			must.Equal(api.BOUND_UNBOUNDED, universal.MaxBound())
+			must.Equal(api.BOUND_CLOSED, atLeast.MinBound())
+			must.Equal(api.BOUND_UNBOUNDED, atLeast.MaxBound())
+			must.Equal(N(10), atLeast.Min())
+			must.Equal(mathutil.MaxValue[N](), atLeast.Max())
+		})
+
+		t.Run("Contains", func(t *testing.T) {
+			must := require.New(t)
+			for _, value := range []N{mathutil.MinValue[N](), 0, 10, 20, mathutil.MaxValue[N]()} {
+				must.True(universal.Contains(value))
+				must.Equal(value >= 10, atLeast.Contains(value))
+				must.Equal(value <= 20, atMost.Contains(value))
+			}
+		})
+
+		t.Run("Operations", func(t *testing.T) {
+			requireInterval(t, mathutil.NewRightUnbounded[N](5, true), mathutil.NewClosed[N](5, 15).Union(atLeast))
+			requireInterval(t, mathutil.NewRightUnbounded[N](5, true), atLeast.Union(mathutil.NewClosed[N](5, 15)))
+			requireInterval(t, mathutil.NewClosed[N](10, 20), atLeast.Intersection(atMost))
+			requireInterval(t, universal, atLeast.Union(atMost))
+			requireInterval(t, mathutil.NewClosed[N](0, 5), mathutil.NewClosed[N](0, 30).Intersection(mathutil.NewLeftUnbounded[N](5, true)))
+			requireInterval(t, mathutil.NewInterval[N](20, false, 30, true), mathutil.NewClosed[N](0, 30).Difference(atMost))
+			require.True(t, atMost.Overlaps(mathutil.NewSingleton[N](0)))
+			require.False(t, atLeast.Overlaps(mathutil.NewClosed[N](0, 5)))
+		})
+
+		t.Run("Complement", func(t *testing.T) {
+			closed := mathutil.NewClosed[N](10, 20)
+			outside := mathutil.RawMerged(mathutil.NewLeftUnbounded[N](10, false), mathutil.NewRightUnbounded[N](20, false))
+			requireInterval(t, outside, closed.Complement())
+			requireInterval(t, closed, outside.Complement())
+			requireInterval(t, mathutil.NewEmpty[N](), universal.Complement())
+			requireInterval(t, universal, mathutil.NewEmpty[N]().Complement())
+			requireInterval(t, mathutil.NewRightUnbounded[N](20, false), atMost.Complement())
+			requireInterval(t, atMost, atMost.Complement().Complement())
+		})
+
+		t.Run("Translate", func(t *testing.T) {
+			requireInterval(t, mathutil.NewRightUnbounded[N](15, true), atLeast.Translate(5, false))
+			requireInterval(t, universal, universal.Translate(5, true))
+		})
+	}
+}
+
+func Test_Interval_Unbounded(t *testing.T) {
+	t.Run("int", Run_Test_Interval_Unbounded[int]())
+	t.Run("int8", Run_Test_Interval_Unbounded[int8]())
+	t.Run("uint", Run_Test_Interval_Unbounded[uint]())
+	t.Run("uint8", Run_Test_Interval_Unbounded[uint8]())
+	t.Run("float32", Run_Test_Interval_Unbounded[float32]())
+	t.Run("float64", Run_Test_Interval_Unbounded[float64]())
+
+	t.Run("SaturatedLen", func(t *testing.T) {
+		require.Equal(t, int8(127), mathutil.NewClosed[int8](-128, 127).Len())
+		require.Equal(t, uint8(255), mathutil.NewClosed[uint8](0, 255).Len())
+		require.Equal(t, int8(127), merged[int8](-128, -1, 1, 127).Len())
+	})
+
+	t.Run("OpenComplement", func(t *testing.T) {
+		requireInterval(t,
+			mathutil.RawMerged(mathutil.NewLeftUnbounded[float64](0, false), mathutil.NewRightUnbounded[float64](1, true)),
+			mathutil.NewInterval[float64](0, true, 1, false).Complement())
+	})
+}
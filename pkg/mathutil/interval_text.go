@@ -0,0 +1,177 @@
+package mathutil
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/toolvox/utilgo/api"
+	"github.com/toolvox/utilgo/pkg/errs"
+)
+
+// Interval wraps an [api.Interval] so it can be decoded from text, like in JSON or YAML configs.
+// The zero value holds no interval, and is encoded as the empty interval.
+type Interval[N Number] struct {
+	api.Interval[N]
+}
+
+// ParseInterval parses an interval written in mathematical notation.
+//
+// Supported forms:
+//
+//	[1, 5]  (2, 8)  [0, 1)  {3}  ∅
+//	(-∞, 5]  [10, ∞)  (-inf, inf)
+//	[1, 3] ∪ [7, 9]  [1, 3] | [7, 9]
+//
+// Unions are merged, so "[1, 3] ∪ [2, 5]" parses as [1, 5]. Every side of a union must hold an interval.
+// Edges which hold no values, like "[5, 1]" or "(2, 2)", are an error, the empty interval is written as ∅ or {}.
+func ParseInterval[N Number](s string) (api.Interval[N], error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, errs.Newf("parse interval '%s': empty input", s)
+	}
+	parts := strings.Split(strings.ReplaceAll(s, "∪", "|"), "|")
+
+	res := make([]api.Interval[N], 0, len(parts))
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			return nil, errs.Newf("parse interval '%s': empty union part %d", s, i+1)
+		}
+		interval, err := parseSimpleInterval[N](part)
+		if err != nil {
+			return nil, fmt.Errorf("parse interval '%s': %w", s, err)
+		}
+		res = append(res, interval)
+	}
+	return NewMerged(res...), nil
+}
+
+func parseSimpleInterval[N Number](s string) (api.Interval[N], error) {
+	switch {
+	case s == "∅" || s == "{}":
+		return NewEmpty[N](), nil
+
+	case strings.HasPrefix(s, "{") && strings.HasSuffix(s, "}"):
+		value, err := ParseNumber[N](strings.TrimSpace(s[1 : len(s)-1]))
+		if err != nil {
+			return nil, err
+		}
+		return NewSingleton(value), nil
+
+	case len(s) < 2 || !strings.ContainsAny(s[:1], "[(") || !strings.ContainsAny(s[len(s)-1:], "])"):
+		return nil, errs.Newf("'%s' is not enclosed in brackets", s)
+	}
+
+	edges := strings.Split(s[1:len(s)-1], ",")
+	if len(edges) != 2 {
+		return nil, errs.Newf("'%s' does not have exactly two edges", s)
+	}
+
+	minBound, maxBound := api.BOUND_CLOSED, api.BOUND_CLOSED
+	if s[0] == '(' {
+		minBound = api.BOUND_OPEN
+	}
+	if s[len(s)-1] == ')' {
+		maxBound = api.BOUND_OPEN
+	}
+
+	var minValue, maxValue N
+	var err error
+	switch edge := strings.TrimSpace(edges[0]); edge {
+	case "-∞", "-inf", "-Inf":
+		minBound = api.BOUND_UNBOUNDED
+	default:
+		if minValue, err = ParseNumber[N](edge); err != nil {
+			return nil, err
+		}
+	}
+	switch edge := strings.TrimSpace(edges[1]); edge {
+	case "∞", "+∞", "inf", "+inf", "Inf", "+Inf":
+		maxBound = api.BOUND_UNBOUNDED
+	default:
+		if maxValue, err = ParseNumber[N](edge); err != nil {
+			return nil, err
+		}
+	}
+
+	if minBound != api.BOUND_UNBOUNDED && maxBound != api.BOUND_UNBOUNDED &&
+		(minValue > maxValue || minValue == maxValue && (minBound == api.BOUND_OPEN || maxBound == api.BOUND_OPEN)) {
+		return nil, errs.Newf("'%s' has no values between its edges, write ∅ for the empty interval", s)
+	}
+	return newPiece(minValue, minBound, maxValue, maxBound).interval(), nil
+}
+
+// formatEdges writes a simple interval in mathematical notation, like [1, 5) or (-∞, 3].
+func formatEdges[N Number](min N, minBound api.BoundType, max N, maxBound api.BoundType) string {
+	var sb strings.Builder
+	switch minBound {
+	case api.BOUND_UNBOUNDED:
+		sb.WriteString("(-∞")
+	case api.BOUND_OPEN:
+		fmt.Fprint(&sb, "(", min)
+	default:
+		fmt.Fprint(&sb, "[", min)
+	}
+	sb.WriteString(", ")
+	switch maxBound {
+	case api.BOUND_UNBOUNDED:
+		sb.WriteString("∞)")
+	case api.BOUND_OPEN:
+		fmt.Fprint(&sb, max, ")")
+	default:
+		fmt.Fprint(&sb, max, "]")
+	}
+	return sb.String()
+}
+
+// String returns the interval in mathematical notation, or ∅ if there is no interval.
+func (i Interval[N]) String() string {
+	if i.Interval == nil {
+		return NewEmpty[N]().String()
+	}
+	return i.Interval.String()
+}
+
+// MarshalText encodes the interval in mathematical notation.
+func (i Interval[N]) MarshalText() ([]byte, error) {
+	return []byte(i.String()), nil
+}
+
+// UnmarshalText decodes an interval written in mathematical notation, see [ParseInterval].
+func (i *Interval[N]) UnmarshalText(text []byte) error {
+	interval, err := ParseInterval[N](string(text))
+	if err != nil {
+		return err
+	}
+	i.Interval = interval
+	return nil
+}
+
+func (ni nullInterval[N]) String() string               { return "∅" }
+func (ni nullInterval[N]) MarshalText() ([]byte, error) { return []byte(ni.String()), nil }
+
+func (si singletonInterval[N]) String() string               { return fmt.Sprint("{", si[0], "}") }
+func (si singletonInterval[N]) MarshalText() ([]byte, error) { return []byte(si.String()), nil }
+
+func (ci closedInterval[N]) String() string {
+	return formatEdges(ci[0], api.BOUND_CLOSED, ci[1], api.BOUND_CLOSED)
+}
+func (ci closedInterval[N]) MarshalText() ([]byte, error) { return []byte(ci.String()), nil }
+
+func (oi openInterval[N]) String() string {
+	return formatEdges(oi.min, oi.MinBound(), oi.max, oi.MaxBound())
+}
+func (oi openInterval[N]) MarshalText() ([]byte, error) { return []byte(oi.String()), nil }
+
+func (ui unboundedInterval[N]) String() string {
+	return formatEdges(ui.min, ui.minBound, ui.max, ui.maxBound)
+}
+func (ui unboundedInterval[N]) MarshalText() ([]byte, error) { return []byte(ui.String()), nil }
+
+func (mis mergedIntervals[N]) String() string {
+	subStrings := make([]string, len(mis))
+	for i, subInt := range mis {
+		subStrings[i] = subInt.String()
+	}
+	return strings.Join(subStrings, " ∪ ")
+}
+func (mis mergedIntervals[N]) MarshalText() ([]byte, error) { return []byte(mis.String()), nil }
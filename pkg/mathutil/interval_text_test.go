@@ -0,0 +1,132 @@
+package mathutil_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/toolvox/utilgo/api"
+	"github.com/toolvox/utilgo/pkg/mathutil"
+)
+
+func Test_Interval_Text(t *testing.T) {
+	t.Run("String", func(t *testing.T) {
+		tests := []struct {
+			interval api.Interval[int]
+			expected string
+		}{
+			{mathutil.NewEmpty[int](), "∅"},
+			{mathutil.NewSingleton(3), "{3}"},
+			{mathutil.NewClosed(-1, 5), "[-1, 5]"},
+			{merged(1, 3, 7, 9), "[1, 3] ∪ [7, 9]"},
+			{mathutil.NewLeftUnbounded(5, false), "(-∞, 4]"},
+			{mathutil.NewRightUnbounded(10, true), "[10, ∞)"},
+			{mathutil.NewUniversal[int](), "(-∞, ∞)"},
+			{mathutil.RawMerged(mathutil.NewSingleton(0), mathutil.NewRightUnbounded(2, true)), "{0} ∪ [2, ∞)"},
+		}
+		for _, tt := range tests {
+			t.Run(tt.expected, func(t *testing.T) {
+				require.Equal(t, tt.expected, tt.interval.String())
+			})
+		}
+
+		require.Equal(t, "[0, 0.5)", mathutil.NewInterval(0, true, 0.5, false).String())
+		require.Equal(t, "(1.5, ∞)", mathutil.NewRightUnbounded(1.5, false).String())
+	})
+
+	t.Run("Parse", func(t *testing.T) {
+		tests := []struct {
+			text     string
+			expected api.Interval[int]
+		}{
+			{"∅", mathutil.NewEmpty[int]()},
+			{"{}", mathutil.NewEmpty[int]()},
+			{"{ 3 }", mathutil.NewSingleton(3)},
+			{"[1,5]", mathutil.NewClosed(1, 5)},
+			{"(2,8)", mathutil.NewClosed(3, 7)},
+			{"[2, 2]", mathutil.NewSingleton(2)},
+			{"[1,3] ∪ [7,9]", merged(1, 3, 7, 9)},
+			{"[1,3]|[7,9]", merged(1, 3, 7, 9)},
+			{"[1, 3] | [2, 5]", mathutil.NewClosed(1, 5)},
+			{"(-∞, 5]", mathutil.NewLeftUnbounded(5, true)},
+			{"[10, inf)", mathutil.NewRightUnbounded(10, true)},
+			{"(-inf, +∞)", mathutil.NewUniversal[int]()},
+		}
+		for _, tt := range tests {
+			t.Run(tt.text, func(t *testing.T) {
+				actual, err := mathutil.ParseInterval[int](tt.text)
+				require.NoError(t, err)
+				requireInterval(t, tt.expected, actual)
+			})
+		}
+
+		actual, err := mathutil.ParseInterval[float64]("[0, 1) ∪ (1, 2.5]")
+		require.NoError(t, err)
+		requireInterval(t, mathutil.RawMerged(mathutil.NewInterval(0, true, 1.0, false), mathutil.NewInterval(1, false, 2.5, true)), actual)
+	})
+
+	t.Run("ParseError", func(t *testing.T) {
+		for _, text := range []string{"", "1, 5", "[1, 5", "[1; 5]", "[a, 5]", "[1, 2, 3]", "{x}", "[1, 300]",
+			"[1,3]||[7,9]", "[1,3] ∪", "∪ [1,3]", "[1,3] ∪ ∪ [7,9]", "|", " ",
+			"[5, 1]", "(2, 2)", "[2, 2)", "[1,3] ∪ [9,7]"} {
+			t.Run(text, func(t *testing.T) {
+				_, err := mathutil.ParseInterval[uint8](text)
+				require.Error(t, err)
+			})
+		}
+	})
+
+	t.Run("RoundTrip", func(t *testing.T) {
+		for _, interval := range []api.Interval[int]{
+			mathutil.NewEmpty[int](),
+			mathutil.NewSingleton(-3),
+			merged(1, 3, 7, 9),
+			mathutil.NewLeftUnbounded(5, true).Union(mathutil.NewSingleton(10)),
+			mathutil.NewClosed(5, 10).Complement(),
+		} {
+			t.Run(interval.String(), func(t *testing.T) {
+				actual, err := mathutil.ParseInterval[int](interval.String())
+				require.NoError(t, err)
+				requireInterval(t, interval, actual)
+			})
+		}
+	})
+
+	t.Run("JSON", func(t *testing.T) {
+		must := require.New(t)
+		type config struct {
+			Window mathutil.Interval[int64] `json:"window"`
+		}
+
+		data, err := json.Marshal(config{mathutil.Interval[int64]{merged[int64](1, 3, 7, 9)}})
+		must.NoError(err)
+		must.Equal(`{"window":"[1, 3] ∪ [7, 9]"}`, string(data))
+
+		var decoded config
+		must.NoError(json.Unmarshal(data, &decoded))
+		requireInterval(t, merged[int64](1, 3, 7, 9), decoded.Window.Interval)
+
+		must.Error(json.Unmarshal([]byte(`{"window":"[1, 3"}`), &decoded))
+
+		data, err = json.Marshal(config{})
+		must.NoError(err)
+		must.Equal(`{"window":"∅"}`, string(data))
+	})
+
+	t.Run("YAML", func(t *testing.T) {
+		must := require.New(t)
+		type config struct {
+			Window mathutil.Interval[float64] `yaml:"window"`
+		}
+
+		data, err := yaml.Marshal(config{mathutil.Interval[float64]{mathutil.NewInterval(0, true, 0.5, false)}})
+		must.NoError(err)
+		must.Equal("window: '[0, 0.5)'\n", string(data))
+
+		var decoded config
+		must.NoError(yaml.Unmarshal(data, &decoded))
+		requireInterval(t, mathutil.NewInterval(0, true, 0.5, false), decoded.Window.Interval)
+	})
+}
@@ -0,0 +1,267 @@
+package mathutil
+
+import (
+	"slices"
+
+	"github.com/toolvox/utilgo/api"
+)
+
+// IntervalTree maps intervals to values, and finds the values of the intervals containing a point or overlapping an interval.
+//
+// Compound intervals are stored as their sub-intervals, and reported once per query.
+// Queries take O(log n + k) for k sub-intervals matched.
+type IntervalTree[N Number, V any] struct {
+	root    *treeNode[N, V]
+	entries int
+	nextSeq uint64
+}
+
+type treeEntry[N Number, V any] struct {
+	interval api.Interval[N]
+	value    V
+	nodes    []*treeNode[N, V]
+}
+
+// treeNode holds one sub-interval of an entry, in a treap ordered by the start of the sub-interval.
+// Each node also tracks the furthest reaching end in its subtree.
+type treeNode[N Number, V any] struct {
+	piece       piece[N]
+	entry       *treeEntry[N, V]
+	seq         uint64
+	priority    uint64
+	maxEnd      piece[N]
+	left, right *treeNode[N, V]
+}
+
+// NewIntervalTree creates an empty [IntervalTree].
+func NewIntervalTree[N Number, V any]() *IntervalTree[N, V] {
+	return &IntervalTree[N, V]{}
+}
+
+// Len counts the intervals in the tree.
+func (t *IntervalTree[N, V]) Len() int { return t.entries }
+
+// Insert adds the interval to the tree with its value.
+// Empty intervals can never be matched and are not added.
+func (t *IntervalTree[N, V]) Insert(interval api.Interval[N], value V) {
+	pieces := coverPieces(piecesOf(interval))
+	if len(pieces) == 0 {
+		return
+	}
+
+	entry := &treeEntry[N, V]{interval: interval, value: value}
+	for _, p := range pieces {
+		t.nextSeq++
+		node := &treeNode[N, V]{
+			piece:    p,
+			entry:    entry,
+			seq:      t.nextSeq,
+			priority: mix(t.nextSeq),
+			maxEnd:   p,
+		}
+		entry.nodes = append(entry.nodes, node)
+		t.root = t.root.insert(node)
+	}
+	t.entries++
+}
+
+// Delete removes every interval equal to the given interval from the tree.
+// Returns whether anything was removed.
+func (t *IntervalTree[N, V]) Delete(interval api.Interval[N]) bool {
+	pieces := coverPieces(piecesOf(interval))
+	if len(pieces) == 0 {
+		return false
+	}
+
+	var matched []*treeEntry[N, V]
+	t.root.eachStartingAt(pieces[0], func(n *treeNode[N, V]) {
+		if n.isFirst() && n.entry.interval.Equals(interval) {
+			matched = append(matched, n.entry)
+		}
+	})
+	for _, entry := range matched {
+		for _, node := range entry.nodes {
+			t.root = t.root.remove(node)
+		}
+	}
+	t.entries -= len(matched)
+	return len(matched) > 0
+}
+
+// Stab returns the values of the intervals containing value, ordered by the start of the intervals.
+func (t *IntervalTree[N, V]) Stab(value N) []V {
+	return t.Overlapping(NewSingleton(value))
+}
+
+// Overlapping returns the values of the intervals overlapping interval, ordered by the start of the intervals.
+func (t *IntervalTree[N, V]) Overlapping(interval api.Interval[N]) []V {
+	seen := map[*treeEntry[N, V]]bool{}
+	var matched []*treeEntry[N, V]
+	for _, q := range coverPieces(piecesOf(interval)) {
+		t.root.eachOverlapping(q, func(n *treeNode[N, V]) {
+			if !seen[n.entry] {
+				seen[n.entry] = true
+				matched = append(matched, n.entry)
+			}
+		})
+	}
+
+	// matches are found by sub-interval, so entries matched by later query pieces may start earlier
+	slices.SortFunc(matched, func(a, b *treeEntry[N, V]) int {
+		if a.nodes[0].before(b.nodes[0]) {
+			return -1
+		}
+		return 1
+	})
+	res := make([]V, len(matched))
+	for i, entry := range matched {
+		res[i] = entry.value
+	}
+	return res
+}
+
+// Walk calls fn with every interval in the tree and its value, ordered by the start of the intervals.
+// Stops early if fn returns false.
+func (t *IntervalTree[N, V]) Walk(fn func(interval api.Interval[N], value V) bool) {
+	t.root.eachInOrder(func(n *treeNode[N, V]) bool {
+		return !n.isFirst() || fn(n.entry.interval, n.entry.value)
+	})
+}
+
+func (n *treeNode[N, V]) isFirst() bool { return n.entry.nodes[0] == n }
+
+func (n *treeNode[N, V]) before(other *treeNode[N, V]) bool {
+	if n.piece.startsBefore(other.piece) {
+		return true
+	}
+	if other.piece.startsBefore(n.piece) {
+		return false
+	}
+	return n.seq < other.seq
+}
+
+func (n *treeNode[N, V]) update() {
+	n.maxEnd = n.piece
+	for _, child := range []*treeNode[N, V]{n.left, n.right} {
+		if child != nil && child.maxEnd.endsAfter(n.maxEnd) {
+			n.maxEnd = child.maxEnd
+		}
+	}
+}
+
+func (n *treeNode[N, V]) rotateRight() *treeNode[N, V] {
+	root := n.left
+	n.left, root.right = root.right, n
+	n.update()
+	root.update()
+	return root
+}
+
+func (n *treeNode[N, V]) rotateLeft() *treeNode[N, V] {
+	root := n.right
+	n.right, root.left = root.left, n
+	n.update()
+	root.update()
+	return root
+}
+
+func (n *treeNode[N, V]) insert(node *treeNode[N, V]) *treeNode[N, V] {
+	if n == nil {
+		return node
+	}
+	if node.before(n) {
+		n.left = n.left.insert(node)
+		if n.left.priority > n.priority {
+			return n.rotateRight()
+		}
+	} else {
+		n.right = n.right.insert(node)
+		if n.right.priority > n.priority {
+			return n.rotateLeft()
+		}
+	}
+	n.update()
+	return n
+}
+
+func (n *treeNode[N, V]) remove(node *treeNode[N, V]) *treeNode[N, V] {
+	switch {
+	case n == nil:
+		return nil
+	case n == node:
+		return n.removeRoot()
+	case node.before(n):
+		n.left = n.left.remove(node)
+	default:
+		n.right = n.right.remove(node)
+	}
+	n.update()
+	return n
+}
+
+func (n *treeNode[N, V]) removeRoot() *treeNode[N, V] {
+	switch {
+	case n.left == nil:
+		return n.right
+	case n.right == nil:
+		return n.left
+	case n.left.priority > n.right.priority:
+		root := n.rotateRight()
+		root.right = n.removeRoot()
+		root.update()
+		return root
+	default:
+		root := n.rotateLeft()
+		root.left = n.removeRoot()
+		root.update()
+		return root
+	}
+}
+
+// eachStartingAt calls fn with every node whose piece starts exactly where p starts.
+func (n *treeNode[N, V]) eachStartingAt(p piece[N], fn func(*treeNode[N, V])) {
+	if n == nil {
+		return
+	}
+	if !n.piece.startsBefore(p) {
+		n.left.eachStartingAt(p, fn)
+	}
+	if !n.piece.startsBefore(p) && !p.startsBefore(n.piece) {
+		fn(n)
+	}
+	if !p.startsBefore(n.piece) {
+		n.right.eachStartingAt(p, fn)
+	}
+}
+
+// eachOverlapping calls fn with every node whose piece overlaps q, in order.
+func (n *treeNode[N, V]) eachOverlapping(q piece[N], fn func(*treeNode[N, V])) {
+	// nothing in the subtree reaches the start of q
+	if n == nil || (piece[N]{q.min, n.maxEnd.max, q.minBound, n.maxEnd.maxBound}).isEmpty() {
+		return
+	}
+	n.left.eachOverlapping(q, fn)
+	if !q.intersect(n.piece).isEmpty() {
+		fn(n)
+	}
+	// the right subtree starts after the end of q
+	if (piece[N]{n.piece.min, q.max, n.piece.minBound, q.maxBound}).isEmpty() {
+		return
+	}
+	n.right.eachOverlapping(q, fn)
+}
+
+func (n *treeNode[N, V]) eachInOrder(fn func(*treeNode[N, V]) bool) bool {
+	if n == nil {
+		return true
+	}
+	return n.left.eachInOrder(fn) && fn(n) && n.right.eachInOrder(fn)
+}
+
+// mix scrambles a sequence number into a treap priority.
+func mix(seq uint64) uint64 {
+	seq += 0x9e3779b97f4a7c15
+	seq = (seq ^ (seq >> 30)) * 0xbf58476d1ce4e5b9
+	seq = (seq ^ (seq >> 27)) * 0x94d049bb133111eb
+	return seq ^ (seq >> 31)
+}
@@ -0,0 +1,138 @@
+package mathutil_test
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/toolvox/utilgo/api"
+	"github.com/toolvox/utilgo/pkg/mathutil"
+)
+
+func Test_IntervalTree(t *testing.T) {
+	t.Run("Stab", func(t *testing.T) {
+		must := require.New(t)
+		tree := mathutil.NewIntervalTree[int64, string]()
+		tree.Insert(mathutil.NewClosed[int64](10, 20), "a")
+		tree.Insert(mathutil.NewClosed[int64](15, 30), "b")
+		tree.Insert(merged[int64](0, 5, 18, 19, 40, 50), "c")
+		tree.Insert(mathutil.NewSingleton[int64](45), "d")
+		tree.Insert(mathutil.NewEmpty[int64](), "never")
+		must.Equal(4, tree.Len())
+
+		must.Empty(tree.Stab(-1))
+		must.Equal([]string{"c"}, tree.Stab(0))
+		must.Equal([]string{"a"}, tree.Stab(12))
+		must.Equal([]string{"a", "b"}, tree.Stab(15))
+		must.Equal([]string{"c", "a", "b"}, tree.Stab(18))
+		must.Equal([]string{"b"}, tree.Stab(30))
+		must.Equal([]string{"c", "d"}, tree.Stab(45))
+	})
+
+	t.Run("Overlapping", func(t *testing.T) {
+		must := require.New(t)
+		tree := mathutil.NewIntervalTree[int, int]()
+		tree.Insert(mathutil.NewClosed(0, 10), 1)
+		tree.Insert(mathutil.NewClosed(20, 30), 2)
+		tree.Insert(merged(5, 6, 25, 26), 3)
+		tree.Insert(mathutil.NewRightUnbounded(100, true), 4)
+
+		must.Equal([]int{1, 3}, tree.Overlapping(mathutil.NewClosed(6, 8)))
+		must.Equal([]int{1, 3, 2}, tree.Overlapping(merged(0, 0, 26, 26)))
+		must.Equal([]int{3, 2}, tree.Overlapping(mathutil.NewClosed(25, 25)))
+		must.Equal([]int{4}, tree.Overlapping(mathutil.NewSingleton(1000)))
+		must.Equal([]int{1, 3, 2, 4}, tree.Overlapping(mathutil.NewUniversal[int]()))
+		must.Empty(tree.Overlapping(mathutil.NewClosed(11, 19)))
+		must.Empty(tree.Overlapping(mathutil.NewEmpty[int]()))
+	})
+
+	t.Run("OpenBounds", func(t *testing.T) {
+		must := require.New(t)
+		tree := mathutil.NewIntervalTree[float64, string]()
+		tree.Insert(mathutil.NewInterval(0.0, true, 1.0, false), "[0,1)")
+		tree.Insert(mathutil.NewInterval(1.0, true, 2.0, false), "[1,2)")
+
+		must.Equal([]string{"[0,1)"}, tree.Stab(0.5))
+		must.Equal([]string{"[1,2)"}, tree.Stab(1))
+		must.Empty(tree.Stab(2))
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		must := require.New(t)
+		tree := mathutil.NewIntervalTree[int, string]()
+		tree.Insert(mathutil.NewClosed(0, 10), "a")
+		tree.Insert(merged(0, 10, 20, 30), "b")
+		tree.Insert(mathutil.NewClosed(0, 10), "c")
+
+		must.False(tree.Delete(mathutil.NewClosed(0, 11)))
+		must.True(tree.Delete(mathutil.NewClosed(0, 10)))
+		must.Equal(1, tree.Len())
+		must.Equal([]string{"b"}, tree.Stab(5))
+		must.True(tree.Delete(merged(0, 10, 20, 30)))
+		must.Equal(0, tree.Len())
+		must.Empty(tree.Stab(25))
+	})
+
+	t.Run("Walk", func(t *testing.T) {
+		must := require.New(t)
+		tree := mathutil.NewIntervalTree[int, string]()
+		tree.Insert(mathutil.NewClosed(20, 30), "c")
+		tree.Insert(merged(0, 1, 50, 60), "a")
+		tree.Insert(mathutil.NewClosed(10, 11), "b")
+
+		var values []string
+		tree.Walk(func(_ api.Interval[int], value string) bool {
+			values = append(values, value)
+			return true
+		})
+		must.Equal([]string{"a", "b", "c"}, values)
+
+		values = nil
+		tree.Walk(func(_ api.Interval[int], value string) bool {
+			values = append(values, value)
+			return false
+		})
+		must.Equal([]string{"a"}, values)
+	})
+
+	t.Run("Random", func(t *testing.T) {
+		rng := rand.New(rand.NewSource(42))
+		tree := mathutil.NewIntervalTree[int, int]()
+		intervals := map[int]api.Interval[int]{}
+		for i := range 500 {
+			start := rng.Intn(1000)
+			interval := mathutil.NewClosed(start, start+rng.Intn(50))
+			if i%3 == 0 {
+				interval = interval.Union(mathutil.NewSingleton(rng.Intn(1000)))
+			}
+			intervals[i] = interval
+			tree.Insert(interval, i)
+		}
+		for i := 0; i < 500; i += 2 {
+			if _, ok := intervals[i]; ok && tree.Delete(intervals[i]) {
+				for j, interval := range intervals {
+					if interval.Equals(intervals[i]) && j != i {
+						delete(intervals, j)
+					}
+				}
+				delete(intervals, i)
+			}
+		}
+		require.Equal(t, len(intervals), tree.Len())
+
+		for q := -10; q < 1060; q += 7 {
+			t.Run(fmt.Sprint(q), func(t *testing.T) {
+				query := mathutil.NewClosed(q, q+3)
+				var expected []int
+				for i, interval := range intervals {
+					if interval.Overlaps(query) {
+						expected = append(expected, i)
+					}
+				}
+				require.ElementsMatch(t, expected, tree.Overlapping(query))
+			})
+		}
+	})
+}
@@ -0,0 +1,92 @@
+package mathutil
+
+import (
+	"github.com/toolvox/utilgo/api"
+)
+
+// unboundedInterval is an interval with at least one unbounded edge.
+// The unbounded edges hold MinValue or MaxValue, so Min and Max stay comparable with other intervals.
+type unboundedInterval[N Number] struct {
+	min, max           N
+	minBound, maxBound api.BoundType
+}
+
+// NewLeftUnbounded creates the interval of all values up to max, like (-∞, max].
+func NewLeftUnbounded[N Number](max N, includeMax bool) api.Interval[N] {
+	return newPiece(MinValue[N](), api.BOUND_UNBOUNDED, max, boundOf(!includeMax)).interval()
+}
+
+// NewRightUnbounded creates the interval of all values from min, like [min, ∞).
+func NewRightUnbounded[N Number](min N, includeMin bool) api.Interval[N] {
+	return newPiece(min, boundOf(!includeMin), MaxValue[N](), api.BOUND_UNBOUNDED).interval()
+}
+
+// NewUniversal creates the interval of all values, like (-∞, ∞).
+func NewUniversal[N Number]() api.Interval[N] {
+	return unboundedInterval[N]{MinValue[N](), MaxValue[N](), api.BOUND_UNBOUNDED, api.BOUND_UNBOUNDED}
+}
+
+func (ui unboundedInterval[N]) Min() N { return ui.min }
+func (ui unboundedInterval[N]) Max() N { return ui.max }
+func (ui unboundedInterval[N]) Len() N { return MaxValue[N]() }
+
+func (ui unboundedInterval[N]) MinBound() api.BoundType { return ui.minBound }
+func (ui unboundedInterval[N]) MaxBound() api.BoundType { return ui.maxBound }
+
+func (ui unboundedInterval[N]) IsEmpty() bool     { return false }
+func (ui unboundedInterval[N]) IsSingleton() bool { return false }
+func (ui unboundedInterval[N]) IsCompound() bool  { return false }
+func (ui unboundedInterval[N]) IsUnbounded() bool { return true }
+
+// Enumerate returns nil, as an unbounded interval has no end to enumerate to.
+func (ui unboundedInterval[N]) Enumerate(_ N) []N { return nil }
+
+func (ui unboundedInterval[N]) Intervals() []api.Interval[N] { return []api.Interval[N]{ui} }
+
+func (ui unboundedInterval[N]) Contains(value N) bool {
+	return (ui.minBound == api.BOUND_UNBOUNDED || value > ui.min || (value == ui.min && ui.minBound == api.BOUND_CLOSED)) &&
+		(ui.maxBound == api.BOUND_UNBOUNDED || value < ui.max || (value == ui.max && ui.maxBound == api.BOUND_CLOSED))
+}
+
+func (ui unboundedInterval[N]) Overlaps(other api.Interval[N]) bool { return overlapsOf[N](ui, other) }
+
+func (ui unboundedInterval[N]) Equals(other api.Interval[N]) bool {
+	if other == nil || other.IsCompound() || !other.IsUnbounded() {
+		return false
+	}
+
+	return ui.min == other.Min() && ui.max == other.Max() &&
+		ui.minBound == other.MinBound() && ui.maxBound == other.MaxBound()
+}
+
+func (ui unboundedInterval[N]) Union(other api.Interval[N]) api.Interval[N] {
+	return unionOf[N](ui, other)
+}
+
+func (ui unboundedInterval[N]) Intersection(other api.Interval[N]) api.Interval[N] {
+	return intersectionOf[N](ui, other)
+}
+
+func (ui unboundedInterval[N]) Difference(other api.Interval[N]) api.Interval[N] {
+	return differenceOf[N](ui, other)
+}
+
+func (ui unboundedInterval[N]) Complement() api.Interval[N] { return complementOf[N](ui) }
+
+// Resize returns the interval unchanged, as an unbounded interval has no finite size.
+func (ui unboundedInterval[N]) Resize(_ N, _ api.GrowFlags) api.Interval[N] { return ui }
+
+// Scale returns the interval unchanged, as an unbounded interval has no finite size.
+func (ui unboundedInterval[N]) Scale(_ float64, _ api.GrowFlags) api.Interval[N] { return ui }
+
+// Translate moves the bounded edge of the interval by offset, saturating at the bounds of N.
+func (ui unboundedInterval[N]) Translate(offset N, back bool) api.Interval[N] {
+	minValue, maxValue := ui.min, ui.max
+	if ui.minBound != api.BOUND_UNBOUNDED {
+		minValue, _ = shift(minValue, offset, back)
+	}
+	if ui.maxBound != api.BOUND_UNBOUNDED {
+		maxValue, _ = shift(maxValue, offset, back)
+	}
+	return newPiece(minValue, ui.minBound, maxValue, ui.maxBound).interval()
+}
@@ -0,0 +1,113 @@
+package mathutil
+
+import (
+	"math/big"
+	"slices"
+
+	"github.com/toolvox/utilgo/pkg/errs"
+)
+
+// ErrOverflow is returned when an integer result does not fit in its type.
+const ErrOverflow = errs.Error("integer overflow")
+
+// CheckedSum calculates the sum of the provided values.
+// It returns [ErrOverflow], and the saturated sum, if the sum of integers does not fit in N.
+// Intermediate sums may overflow, as long as the final one fits.
+func CheckedSum[N Number](values ...N) (N, error) {
+	var sum N
+	for i, v := range values {
+		next, ok := addChecked(sum, v)
+		if !ok {
+			return checkedBig[N](bigSum(values[i:], bigOf(sum)))
+		}
+		sum = next
+	}
+	return sum, nil
+}
+
+// CheckedProduct calculates the product of the provided values.
+// It returns [ErrOverflow], and the saturated product, if the product of integers does not fit in N.
+// Intermediate products may overflow, as long as the final one fits.
+func CheckedProduct[N Number](values ...N) (N, error) {
+	if len(values) == 0 {
+		return 0, nil
+	}
+	prod := values[0]
+	for i, v := range values[1:] {
+		next, ok := mulChecked(prod, v)
+		if !ok {
+			if slices.Contains(values[i+1:], 0) {
+				return 0, nil
+			}
+			res := bigOf(prod)
+			for _, v := range values[i+1:] {
+				res.Mul(res, bigOf(v))
+			}
+			return checkedBig[N](res)
+		}
+		prod = next
+	}
+	return prod, nil
+}
+
+// checkedBig converts the exact result b to N, returning [ErrOverflow] with the saturated value if it does not fit.
+func checkedBig[N Number](b *big.Int) (N, error) {
+	if b.Cmp(bigOf(MaxValue[N]())) > 0 || b.Cmp(bigOf(MinValue[N]())) < 0 {
+		return fromBig[N](b), ErrOverflow
+	}
+	return fromBig[N](b), nil
+}
+
+// addChecked adds a and b, reporting whether the integer sum fits in N.
+func addChecked[N Number](a, b N) (N, bool) {
+	if !isInteger[N]() {
+		return a + b, true
+	}
+	if (b > 0 && a > MaxValue[N]()-b) || (b < 0 && a < MinValue[N]()-b) {
+		return 0, false
+	}
+	return a + b, true
+}
+
+// mulChecked multiplies a and b, reporting whether the integer product fits in N.
+func mulChecked[N Number](a, b N) (N, bool) {
+	if !isInteger[N]() || a == 0 || b == 0 {
+		return a * b, true
+	}
+	if MinValue[N]() < 0 && ((a == MinValue[N]() && b == N(0)-1) || (b == MinValue[N]() && a == N(0)-1)) {
+		return 0, false
+	}
+	res := a * b
+	if res/b != a {
+		return 0, false
+	}
+	return res, true
+}
+
+func bigOf[N Number](v N) *big.Int {
+	if MinValue[N]() < 0 {
+		return big.NewInt(int64(v))
+	}
+	return new(big.Int).SetUint64(uint64(v))
+}
+
+func bigSum[N Number](values []N, sum *big.Int) *big.Int {
+	for _, v := range values {
+		sum.Add(sum, bigOf(v))
+	}
+	return sum
+}
+
+// fromBig converts b to N, saturating at the bounds of N.
+func fromBig[N Number](b *big.Int) N {
+	switch {
+	case b.Cmp(bigOf(MaxValue[N]())) > 0:
+		return MaxValue[N]()
+	case b.Cmp(bigOf(MinValue[N]())) < 0:
+		return MinValue[N]()
+	case MinValue[N]() < 0:
+		return N(b.Int64())
+	default:
+		return N(b.Uint64())
+	}
+}
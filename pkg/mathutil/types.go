@@ -1,7 +1,10 @@
 package mathutil
 
 import (
+	"fmt"
 	"math"
+	"reflect"
+	"strconv"
 	"unsafe"
 
 	"github.com/toolvox/utilgo/api"
@@ -89,6 +92,41 @@ func One[N Number]() N {
 	return N(1)
 }
 
+// ParseNumber parses a base 10 number of type N, failing if it is out of the range of N.
+func ParseNumber[N Number](s string) (N, error) {
+	var res N
+	value := reflect.ValueOf(&res).Elem()
+	bits := sizeof[N]() * 8
+
+	switch value.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		v, err := strconv.ParseInt(s, 10, bits)
+		if err != nil {
+			return res, fmt.Errorf("parsing '%s' to int: %w", s, err)
+		}
+		value.SetInt(v)
+
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		v, err := strconv.ParseUint(s, 10, bits)
+		if err != nil {
+			return res, fmt.Errorf("parsing '%s' to uint: %w", s, err)
+		}
+		value.SetUint(v)
+
+	default:
+		v, err := strconv.ParseFloat(s, bits)
+		if err != nil {
+			return res, fmt.Errorf("parsing '%s' to float: %w", s, err)
+		}
+		value.SetFloat(v)
+	}
+	return res, nil
+}
+
+func isInteger[N Number]() bool {
+	return EpsilonValue[N]() == One[N]()
+}
+
 func sizeof[T any]() int {
 	var val T
 	return int(unsafe.Sizeof(val))
@@ -1,14 +1,15 @@
 // Package randutil provides various random-related helpers and utilities.
 package randutil
 
-import "math/rand"
+import (
+	"math/rand"
+
+	"github.com/toolvox/utilgo/pkg/sliceutil"
+)
 
 // Derange returns a derangement of ints of size n.
 func Derange(rng *rand.Rand, n int) []int {
-	arr := make([]int, n)
-	for i := range n {
-		arr[i] = i
-	}
+	arr := indices(n)
 
 	for i := n - 1; i > 0; i-- {
 		j := rng.Intn(i + 1)
@@ -30,3 +31,26 @@ func Derange(rng *rand.Rand, n int) []int {
 
 	return arr
 }
+
+// Permutation returns a uniformly random permutation of the ints [0, n), and its rank.
+// The rank reproduces it with [sliceutil.NthPermutation], and is limited to n <= 20 to fit an int.
+func Permutation(rng *rand.Rand, n int) ([]int, int) {
+	rank := rng.Intn(sliceutil.Factorial(n))
+	return sliceutil.NthPermutation(indices(n), rank), rank
+}
+
+// Combination returns a uniformly random choice of k ascending ints of [0, n), and its rank.
+// The rank reproduces it with [sliceutil.NthCombination]. It panics if k is not in [0, n].
+func Combination(rng *rand.Rand, n, k int) ([]int, int) {
+	rank := rng.Intn(sliceutil.Binomial(n, k))
+	return sliceutil.NthCombination(indices(n), k, rank), rank
+}
+
+// indices returns the ints [0, n).
+func indices(n int) []int {
+	arr := make([]int, n)
+	for i := range n {
+		arr[i] = i
+	}
+	return arr
+}
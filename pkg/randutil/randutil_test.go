@@ -3,9 +3,11 @@ package randutil_test
 import (
 	"fmt"
 	"math/rand"
+	"slices"
 	"testing"
 
 	"github.com/toolvox/utilgo/pkg/randutil"
+	"github.com/toolvox/utilgo/pkg/sliceutil"
 )
 
 func Test_Derange(t *testing.T) {
@@ -33,3 +35,23 @@ func Benchmark_Derange(b *testing.B) {
 		})
 	}
 }
+
+func Test_Permutation(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for range 100 {
+		perm, rank := randutil.Permutation(rng, 6)
+		if !slices.Equal(perm, sliceutil.NthPermutation([]int{0, 1, 2, 3, 4, 5}, rank)) {
+			t.Errorf("permutation %v is not rank %d", perm, rank)
+		}
+	}
+}
+
+func Test_Combination(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	for range 100 {
+		combo, rank := randutil.Combination(rng, 10, 4)
+		if len(combo) != 4 || !slices.IsSorted(combo) || sliceutil.CombinationRank(10, combo) != rank {
+			t.Errorf("combination %v is not rank %d", combo, rank)
+		}
+	}
+}
@@ -0,0 +1,497 @@
+package reflectutil
+
+import (
+	"encoding"
+	"fmt"
+	"math"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// ErrConvert is returned when a value can not be converted to the type of its destination.
+const ErrConvert = convertError("cannot convert")
+
+// convertError is a constant error like errs.Error, which can not be used here as errs imports this package.
+type convertError string
+
+func (e convertError) Error() string { return string(e) }
+
+// defaultTags are the struct tags read by [ToMap] and [FromMap] when the options list none.
+var defaultTags = []string{"json", "yaml"}
+
+// ConvertOptions configures [ToMap] and [FromMap]. The zero value reads the json and yaml tags and converts strictly.
+type ConvertOptions struct {
+	// Tags are the struct tags naming fields, in order of precedence, like `json:"name,omitempty"`.
+	// Fields without any are named as in go.
+	// A name of "-" skips the field, the option "omitempty" skips it when empty,
+	// and the option "inline" merges the fields of a nested struct into its parent, like embedded structs.
+	Tags []string
+	// WeaklyTyped lets [FromMap] parse strings into numbers and bools, and format numbers and bools into strings.
+	WeaklyTyped bool
+}
+
+// ToMap converts a struct, or a pointer to one, to a map of its fields by their names.
+//
+// Nested structs become nested maps, also in slices and maps, which become []any and maps of any with the same keys.
+// Values of other types are kept as they are,
+// and structs implementing [encoding.TextMarshaler], like [time.Time], are kept as values.
+// Unexported fields are included only if a tag names them.
+// Like in [encoding/json], a value containing itself, through pointers, slices or maps, returns an error.
+func ToMap(v any, options ConvertOptions) (map[string]any, error) {
+	m := mapper{ConvertOptions: options, visiting: map[copyKey]bool{}}
+	rv := reflect.ValueOf(v)
+	for rv.Kind() == reflect.Pointer && !rv.IsNil() {
+		m.visiting[copyKey{ptr: rv.UnsafePointer(), typ: rv.Type()}] = true
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct || isLeaf(rv.Type()) {
+		return nil, fmt.Errorf("%w: %T to map", ErrConvert, v)
+	}
+	return m.structToMap(rv, "")
+}
+
+// FromMap sets the fields of the struct the target points to from the map, the inverse of [ToMap].
+//
+// Values are converted to the types of the fields: nested maps to structs and maps, slices element by element,
+// numbers to other numeric types if they fit exactly, and strings with [encoding.TextUnmarshaler] to values.
+// Keys match the names of fields exactly, or else case-insensitively, like in [encoding/json].
+// Keys without a matching field are ignored.
+func FromMap(m map[string]any, target any, options ConvertOptions) error {
+	rv := reflect.ValueOf(target)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("%w: map to %T", ErrConvert, target)
+	}
+	return options.assign(rv.Elem(), m, "")
+}
+
+// structField is a field of a struct converted by name, possibly promoted from embedded structs.
+type structField struct {
+	name      string
+	index     []int
+	omitEmpty bool
+}
+
+func (o ConvertOptions) tags() []string {
+	if o.Tags == nil {
+		return defaultTags
+	}
+	return o.Tags
+}
+
+// fields lists the converted fields of the struct type, with shallower fields hiding deeper ones of the same name.
+func (o ConvertOptions) fields(t reflect.Type) []structField {
+	var fields []structField
+	var walk func(t reflect.Type, index []int)
+	walk = func(t reflect.Type, index []int) {
+		for i := range t.NumField() {
+			sf := t.Field(i)
+			name, options, skip := o.tagOf(sf)
+			if skip {
+				continue
+			}
+			fieldIndex := append(slices.Clone(index), i)
+
+			ft := indirect(sf.Type)
+			inline := slices.Contains(options, "inline") || (sf.Anonymous && name == "")
+			if inline && ft.Kind() == reflect.Struct && !isLeaf(ft) {
+				walk(ft, fieldIndex)
+				continue
+			}
+			if !sf.IsExported() && name == "" {
+				continue
+			}
+			if name == "" {
+				name = sf.Name
+			}
+			fields = append(fields, structField{name: name, index: fieldIndex, omitEmpty: slices.Contains(options, "omitempty")})
+		}
+	}
+	walk(t, nil)
+
+	slices.SortStableFunc(fields, func(a, b structField) int { return len(a.index) - len(b.index) })
+	seen := map[string]bool{}
+	return slices.DeleteFunc(fields, func(f structField) bool {
+		hidden := seen[f.name]
+		seen[f.name] = true
+		return hidden
+	})
+}
+
+// tagOf returns the name and options of the field from the first of the tags it has, and whether to skip it.
+// Like in [encoding/json], a tag of "-" skips the field, and "-," names it "-".
+func (o ConvertOptions) tagOf(sf reflect.StructField) (string, []string, bool) {
+	for _, tag := range o.tags() {
+		value, ok := sf.Tag.Lookup(tag)
+		if !ok {
+			continue
+		}
+		if value == "-" {
+			return "", nil, true
+		}
+		name, options, _ := strings.Cut(value, ",")
+		return name, strings.Split(options, ","), false
+	}
+	return "", nil, false
+}
+
+// isLeaf checks whether the struct type is converted as a value rather than by its fields.
+func isLeaf(t reflect.Type) bool {
+	textMarshaler := reflect.TypeFor[encoding.TextMarshaler]()
+	return t.Implements(textMarshaler) || reflect.PointerTo(t).Implements(textMarshaler)
+}
+
+// fieldOf returns the field at the index of the addressable struct, and false if it is in a nil embedded pointer.
+// Unexported fields are made accessible.
+func fieldOf(rv reflect.Value, index []int, allocate bool) (reflect.Value, bool) {
+	for i, x := range index {
+		if i > 0 && rv.Kind() == reflect.Pointer {
+			if rv.IsNil() {
+				if !allocate {
+					return reflect.Value{}, false
+				}
+				rv.Set(reflect.New(rv.Type().Elem()))
+			}
+			rv = rv.Elem()
+		}
+		rv = accessible(rv.Field(x))
+	}
+	return rv, true
+}
+
+// mapper converts structs to maps for [ToMap], tracking the pointers, slices and maps it is inside of to detect cycles.
+type mapper struct {
+	ConvertOptions
+	visiting map[copyKey]bool
+}
+
+// enter marks the pointer, slice or map as being converted, returning an error wrapping [ErrConvert] if it already is.
+// The returned function unmarks it, so values shared without a cycle are converted each time.
+func (m mapper) enter(rv reflect.Value, path string) (func(), error) {
+	key := copyKey{ptr: rv.UnsafePointer(), typ: rv.Type()}
+	if rv.Kind() == reflect.Slice {
+		key.len = rv.Len()
+	}
+	if m.visiting[key] {
+		return nil, fmt.Errorf("%w: %s: encountered a cycle via %s", ErrConvert, path, rv.Type())
+	}
+	m.visiting[key] = true
+	return func() { delete(m.visiting, key) }, nil
+}
+
+func (m mapper) structToMap(rv reflect.Value, path string) (map[string]any, error) {
+	if !rv.CanAddr() {
+		addressable := reflect.New(rv.Type()).Elem()
+		addressable.Set(rv)
+		rv = addressable
+	}
+	result := map[string]any{}
+	for _, field := range m.fields(rv.Type()) {
+		fv, ok := fieldOf(rv, field.index, false)
+		if !ok || (field.omitEmpty && isEmpty(fv)) {
+			continue
+		}
+		value, err := m.toValue(fv, joinPath(path, field.name))
+		if err != nil {
+			return nil, err
+		}
+		result[field.name] = value
+	}
+	return result, nil
+}
+
+// toValue converts the value, turning structs in it into maps.
+func (m mapper) toValue(rv reflect.Value, path string) (any, error) {
+	if !rv.IsValid() {
+		return nil, nil
+	}
+	if !needsConversion(rv.Type()) {
+		return rv.Interface(), nil
+	}
+	switch rv.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if rv.IsNil() {
+			return nil, nil
+		}
+		if rv.Kind() == reflect.Pointer {
+			leave, err := m.enter(rv, path)
+			if err != nil {
+				return nil, err
+			}
+			defer leave()
+		}
+		return m.toValue(rv.Elem(), path)
+	case reflect.Struct:
+		return m.structToMap(rv, path)
+	case reflect.Slice, reflect.Array:
+		if rv.Kind() == reflect.Slice && rv.IsNil() {
+			return []any(nil), nil
+		}
+		if rv.Kind() == reflect.Slice && rv.Len() > 0 {
+			leave, err := m.enter(rv, path)
+			if err != nil {
+				return nil, err
+			}
+			defer leave()
+		}
+		result := make([]any, rv.Len())
+		for i := range result {
+			value, err := m.toValue(rv.Index(i), joinPath(path, strconv.Itoa(i)))
+			if err != nil {
+				return nil, err
+			}
+			result[i] = value
+		}
+		return result, nil
+	case reflect.Map:
+		result := reflect.MakeMapWithSize(reflect.MapOf(rv.Type().Key(), reflect.TypeFor[any]()), rv.Len())
+		if rv.IsNil() {
+			return reflect.Zero(result.Type()).Interface(), nil
+		}
+		leave, err := m.enter(rv, path)
+		if err != nil {
+			return nil, err
+		}
+		defer leave()
+		for iter := rv.MapRange(); iter.Next(); {
+			v, err := m.toValue(iter.Value(), joinPath(path, fmt.Sprint(iter.Key())))
+			if err != nil {
+				return nil, err
+			}
+			value := reflect.New(result.Type().Elem()).Elem()
+			if v != nil {
+				value.Set(reflect.ValueOf(v))
+			}
+			result.SetMapIndex(iter.Key(), value)
+		}
+		return result.Interface(), nil
+	}
+	return rv.Interface(), nil
+}
+
+// needsConversion checks whether values of the type hold structs which [ToMap] turns into maps.
+func needsConversion(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Struct:
+		return !isLeaf(t)
+	case reflect.Pointer, reflect.Slice, reflect.Array, reflect.Map:
+		return needsConversion(t.Elem())
+	case reflect.Interface:
+		return true
+	}
+	return false
+}
+
+// isEmpty checks whether the value is empty for "omitempty", like in [encoding/json].
+func isEmpty(rv reflect.Value) bool {
+	switch rv.Kind() {
+	case reflect.Array, reflect.Map, reflect.Slice, reflect.String:
+		return rv.Len() == 0
+	case reflect.Pointer, reflect.Interface:
+		return rv.IsNil()
+	}
+	return rv.IsZero()
+}
+
+// assign sets the settable destination from the value, converting it to the destination's type.
+func (o ConvertOptions) assign(dst reflect.Value, value any, path string) error {
+	if value == nil {
+		dst.SetZero()
+		return nil
+	}
+	src := reflect.ValueOf(value)
+	if src.Type().AssignableTo(dst.Type()) {
+		dst.Set(src)
+		return nil
+	}
+	fail := func() error {
+		return fmt.Errorf("%w: %s: %T to %s", ErrConvert, path, value, dst.Type())
+	}
+
+	if dst.Kind() == reflect.Pointer {
+		if dst.IsNil() {
+			dst.Set(reflect.New(dst.Type().Elem()))
+		}
+		return o.assign(dst.Elem(), value, path)
+	}
+	if text, ok := value.(string); ok && dst.CanAddr() {
+		if unmarshaler, ok := dst.Addr().Interface().(encoding.TextUnmarshaler); ok {
+			if err := unmarshaler.UnmarshalText([]byte(text)); err != nil {
+				return fmt.Errorf("%w: %s: %w", ErrConvert, path, err)
+			}
+			return nil
+		}
+	}
+
+	switch dst.Kind() {
+	case reflect.Struct:
+		m, ok := value.(map[string]any)
+		if !ok {
+			return fail()
+		}
+		for _, field := range o.fields(dst.Type()) {
+			fieldValue, ok := lookupField(m, field.name)
+			if !ok {
+				continue
+			}
+			fv, _ := fieldOf(dst, field.index, true)
+			if err := o.assign(fv, fieldValue, joinPath(path, field.name)); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case reflect.Slice, reflect.Array:
+		if src.Kind() != reflect.Slice && src.Kind() != reflect.Array {
+			return fail()
+		}
+		if dst.Kind() == reflect.Array && dst.Len() != src.Len() {
+			return fmt.Errorf("%w: %s: %d elements to %s", ErrConvert, path, src.Len(), dst.Type())
+		}
+		result := dst
+		if dst.Kind() == reflect.Slice {
+			result = reflect.MakeSlice(dst.Type(), src.Len(), src.Len())
+		}
+		for i := range src.Len() {
+			if err := o.assign(result.Index(i), src.Index(i).Interface(), joinPath(path, strconv.Itoa(i))); err != nil {
+				return err
+			}
+		}
+		dst.Set(result)
+		return nil
+
+	case reflect.Map:
+		if src.Kind() != reflect.Map {
+			return fail()
+		}
+		result := reflect.MakeMapWithSize(dst.Type(), src.Len())
+		for iter := src.MapRange(); iter.Next(); {
+			keyPath := joinPath(path, fmt.Sprint(iter.Key().Interface()))
+			key := reflect.New(dst.Type().Key()).Elem()
+			if err := o.assign(key, iter.Key().Interface(), keyPath); err != nil {
+				return err
+			}
+			elem := reflect.New(dst.Type().Elem()).Elem()
+			if err := o.assign(elem, iter.Value().Interface(), keyPath); err != nil {
+				return err
+			}
+			result.SetMapIndex(key, elem)
+		}
+		dst.Set(result)
+		return nil
+	}
+
+	if o.assignScalar(dst, src) {
+		return nil
+	}
+	return fail()
+}
+
+// lookupField returns the value of the key matching the field name, exactly or else case-insensitively.
+// Of several keys differing only in case, the smallest is used.
+func lookupField(m map[string]any, name string) (any, bool) {
+	if value, ok := m[name]; ok {
+		return value, true
+	}
+	var match string
+	var found bool
+	for key := range m {
+		if strings.EqualFold(key, name) && (!found || key < match) {
+			match, found = key, true
+		}
+	}
+	return m[match], found
+}
+
+// assignScalar sets numbers from numbers that fit exactly, and with weak typing, strings from and to numbers and bools.
+func (o ConvertOptions) assignScalar(dst, src reflect.Value) bool {
+	if isNumber(src.Kind()) && isNumber(dst.Kind()) {
+		return setNumber(dst, src)
+	}
+	if src.Kind() == dst.Kind() && src.Type().ConvertibleTo(dst.Type()) {
+		dst.Set(src.Convert(dst.Type()))
+		return true
+	}
+	if !o.WeaklyTyped {
+		return false
+	}
+
+	switch {
+	case dst.Kind() == reflect.String && (isNumber(src.Kind()) || src.Kind() == reflect.Bool):
+		dst.SetString(fmt.Sprint(src.Interface()))
+		return true
+	case src.Kind() == reflect.String && dst.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(src.String())
+		if err != nil {
+			return false
+		}
+		dst.SetBool(b)
+		return true
+	case src.Kind() == reflect.String && isNumber(dst.Kind()):
+		if i, err := strconv.ParseInt(src.String(), 0, 64); err == nil {
+			return setNumber(dst, reflect.ValueOf(i))
+		}
+		if u, err := strconv.ParseUint(src.String(), 0, 64); err == nil {
+			return setNumber(dst, reflect.ValueOf(u))
+		}
+		if f, err := strconv.ParseFloat(src.String(), 64); err == nil {
+			return setNumber(dst, reflect.ValueOf(f))
+		}
+	}
+	return false
+}
+
+func isNumber(kind reflect.Kind) bool {
+	return (kind >= reflect.Int && kind <= reflect.Uintptr) || kind == reflect.Float32 || kind == reflect.Float64
+}
+
+// setNumber sets the numeric destination from the numeric source, if it fits exactly.
+func setNumber(dst, src reflect.Value) bool {
+	switch {
+	case src.CanInt():
+		i := src.Int()
+		switch {
+		case dst.CanInt() && !dst.OverflowInt(i):
+			dst.SetInt(i)
+		case dst.CanUint() && i >= 0 && !dst.OverflowUint(uint64(i)):
+			dst.SetUint(uint64(i))
+		case dst.CanFloat() && int64(float64(i)) == i:
+			dst.SetFloat(float64(i))
+		default:
+			return false
+		}
+	case src.CanUint():
+		u := src.Uint()
+		switch {
+		case dst.CanInt() && u <= math.MaxInt64 && !dst.OverflowInt(int64(u)):
+			dst.SetInt(int64(u))
+		case dst.CanUint() && !dst.OverflowUint(u):
+			dst.SetUint(u)
+		case dst.CanFloat() && uint64(float64(u)) == u:
+			dst.SetFloat(float64(u))
+		default:
+			return false
+		}
+	default:
+		f := src.Float()
+		switch {
+		case dst.CanFloat() && (dst.Kind() == reflect.Float64 || float64(float32(f)) == f || math.IsNaN(f)):
+			dst.SetFloat(f)
+		case dst.CanInt() && f == math.Trunc(f) && f >= math.MinInt64 && f < math.MaxInt64 && !dst.OverflowInt(int64(f)):
+			dst.SetInt(int64(f))
+		case dst.CanUint() && f == math.Trunc(f) && f >= 0 && f < math.MaxUint64 && !dst.OverflowUint(uint64(f)):
+			dst.SetUint(uint64(f))
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+func joinPath(prefix, key string) string {
+	if prefix == "" {
+		return key
+	}
+	return prefix + "." + key
+}
@@ -0,0 +1,246 @@
+package reflectutil_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/toolvox/utilgo/pkg/reflectutil"
+)
+
+type convertBase struct {
+	ID      int    `json:"id"`
+	Comment string `json:"comment,omitempty"`
+}
+
+type ConvertAudit struct {
+	By string `yaml:"by"`
+}
+
+type convertLimits struct {
+	Min, Max uint8
+}
+
+type convertServer struct {
+	convertBase
+	*ConvertAudit
+	Name     string                   `json:"name"`
+	Port     int                      `json:"port"`
+	Ratio    float32                  `json:"ratio"`
+	Started  time.Time                `json:"started"`
+	Timeout  *time.Duration           `json:"timeout"`
+	Tags     []string                 `json:"tags"`
+	Limits   convertLimits            `json:"limits"`
+	Backup   *convertLimits           `json:"backup"`
+	Replicas []convertLimits          `json:"replicas"`
+	ByZone   map[string]convertLimits `json:"by_zone"`
+	Extra    any                      `json:"extra"`
+	Flat     convertLimits            `yaml:",inline"`
+	secret   string                   `yaml:"secret"`
+	hidden   string
+	Skipped  string `json:"-"`
+	Dash     string `json:"-,"`
+}
+
+func newConvertServer() convertServer {
+	timeout := 3 * time.Second
+	return convertServer{
+		convertBase:  convertBase{ID: 7},
+		ConvertAudit: &ConvertAudit{By: "ops"},
+		Name:         "api",
+		Port:         8080,
+		Ratio:        0.5,
+		Started:      time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		Timeout:      &timeout,
+		Tags:         []string{"a", "b"},
+		Limits:       convertLimits{1, 2},
+		Replicas:     []convertLimits{{3, 4}},
+		ByZone:       map[string]convertLimits{"eu": {5, 6}},
+		Extra:        convertLimits{7, 8},
+		Flat:         convertLimits{9, 10},
+		secret:       "s3cr3t",
+		hidden:       "hidden",
+		Skipped:      "skipped",
+		Dash:         "dash",
+	}
+}
+
+func Test_ToMap(t *testing.T) {
+	must := require.New(t)
+	server := newConvertServer()
+
+	m, err := reflectutil.ToMap(&server, reflectutil.ConvertOptions{})
+	must.NoError(err)
+	must.Equal(map[string]any{
+		"id":       7,
+		"by":       "ops",
+		"name":     "api",
+		"port":     8080,
+		"ratio":    float32(0.5),
+		"started":  server.Started,
+		"timeout":  server.Timeout,
+		"tags":     []string{"a", "b"},
+		"limits":   map[string]any{"Min": uint8(1), "Max": uint8(2)},
+		"backup":   nil,
+		"replicas": []any{map[string]any{"Min": uint8(3), "Max": uint8(4)}},
+		"by_zone":  map[string]any{"eu": map[string]any{"Min": uint8(5), "Max": uint8(6)}},
+		"extra":    map[string]any{"Min": uint8(7), "Max": uint8(8)},
+		"Min":      uint8(9),
+		"Max":      uint8(10),
+		"secret":   "s3cr3t",
+		"-":        "dash",
+	}, m)
+
+	m, err = reflectutil.ToMap(server, reflectutil.ConvertOptions{Tags: []string{"custom"}})
+	must.NoError(err)
+	must.Contains(m, "Name")
+	must.Contains(m, "Skipped")
+	must.NotContains(m, "secret")
+	must.Contains(m, "ID")
+
+	_, err = reflectutil.ToMap(42, reflectutil.ConvertOptions{})
+	must.ErrorIs(err, reflectutil.ErrConvert)
+	_, err = reflectutil.ToMap(time.Now(), reflectutil.ConvertOptions{})
+	must.ErrorIs(err, reflectutil.ErrConvert)
+}
+
+type convertNode struct {
+	Name     string
+	Parent   *convertNode
+	Children []any
+	Links    map[string]any
+}
+
+func Test_ToMap_Cycles(t *testing.T) {
+	must := require.New(t)
+	node := &convertNode{Name: "root"}
+	node.Parent = node
+	_, err := reflectutil.ToMap(node, reflectutil.ConvertOptions{})
+	must.ErrorIs(err, reflectutil.ErrConvert)
+	must.ErrorContains(err, "Parent: encountered a cycle via *reflectutil_test.convertNode")
+
+	node = &convertNode{Name: "root", Children: []any{nil}}
+	node.Children[0] = node.Children
+	_, err = reflectutil.ToMap(*node, reflectutil.ConvertOptions{})
+	must.ErrorContains(err, "Children.0: encountered a cycle via []interface {}")
+
+	node = &convertNode{Name: "root", Links: map[string]any{}}
+	node.Links["self"] = node.Links
+	_, err = reflectutil.ToMap(node, reflectutil.ConvertOptions{})
+	must.ErrorContains(err, "Links.self: encountered a cycle via map[string]interface {}")
+
+	shared := &convertNode{Name: "shared"}
+	m, err := reflectutil.ToMap(convertNode{Children: []any{shared, shared}, Links: map[string]any{"a": shared}}, reflectutil.ConvertOptions{})
+	must.NoError(err, "values shared without a cycle are converted each time")
+	sharedMap := map[string]any{"Name": "shared", "Parent": nil, "Children": []any(nil), "Links": map[string]any(nil)}
+	must.Equal([]any{sharedMap, sharedMap}, m["Children"])
+	must.Equal(map[string]any{"a": sharedMap}, m["Links"])
+}
+
+func Test_FromMap(t *testing.T) {
+	t.Run("RoundTrip", func(t *testing.T) {
+		must := require.New(t)
+		server := newConvertServer()
+		server.Backup = &convertLimits{11, 12}
+		m, err := reflectutil.ToMap(server, reflectutil.ConvertOptions{})
+		must.NoError(err)
+
+		var result convertServer
+		must.NoError(reflectutil.FromMap(m, &result, reflectutil.ConvertOptions{}))
+		server.hidden, server.Skipped = "", ""
+		// Extra is an interface, so its struct comes back as a map.
+		must.Equal(m["extra"], result.Extra)
+		server.Extra = result.Extra
+		must.Equal(server, result)
+	})
+
+	t.Run("Numbers", func(t *testing.T) {
+		must := require.New(t)
+		var limits struct {
+			Min    uint8
+			Max    int64
+			Ratio  float32
+			Counts []int
+			ByID   map[int]string
+		}
+		must.NoError(reflectutil.FromMap(map[string]any{
+			"Min":    float64(200),
+			"Max":    uint(5),
+			"Ratio":  1,
+			"Counts": []any{1.0, 2},
+			"ByID":   map[float64]any{1: "a"},
+		}, &limits, reflectutil.ConvertOptions{}))
+		must.Equal(uint8(200), limits.Min)
+		must.Equal(int64(5), limits.Max)
+		must.Equal(float32(1), limits.Ratio)
+		must.Equal([]int{1, 2}, limits.Counts)
+		must.Equal(map[int]string{1: "a"}, limits.ByID)
+
+		for _, value := range []any{300, -1, 1.5, "7"} {
+			err := reflectutil.FromMap(map[string]any{"Min": value}, &limits, reflectutil.ConvertOptions{})
+			must.ErrorIs(err, reflectutil.ErrConvert, "%v", value)
+			must.ErrorContains(err, "Min")
+		}
+	})
+
+	t.Run("WeaklyTyped", func(t *testing.T) {
+		must := require.New(t)
+		var config struct {
+			Port    int
+			Debug   bool
+			Name    string
+			Ratio   float64
+			Started time.Time
+			Limits  []convertLimits
+		}
+		weak := reflectutil.ConvertOptions{WeaklyTyped: true}
+		must.NoError(reflectutil.FromMap(map[string]any{
+			"Port":    "8080",
+			"Debug":   "true",
+			"Name":    42,
+			"Ratio":   "0.25",
+			"Started": "2024-01-02T03:04:05Z",
+			"Limits":  []any{map[string]any{"Min": "1", "Max": 2}},
+		}, &config, weak))
+		must.Equal(8080, config.Port)
+		must.True(config.Debug)
+		must.Equal("42", config.Name)
+		must.Equal(0.25, config.Ratio)
+		must.Equal(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC), config.Started)
+		must.Equal([]convertLimits{{1, 2}}, config.Limits)
+
+		err := reflectutil.FromMap(map[string]any{"Limits": []any{map[string]any{"Min": "x"}}}, &config, weak)
+		must.ErrorIs(err, reflectutil.ErrConvert)
+		must.ErrorContains(err, "Limits.0.Min: string to uint8")
+		must.ErrorIs(reflectutil.FromMap(map[string]any{"Port": "8080"}, &config, reflectutil.ConvertOptions{}), reflectutil.ErrConvert)
+		must.ErrorIs(reflectutil.FromMap(map[string]any{"Started": "never"}, &config, weak), reflectutil.ErrConvert)
+	})
+
+	t.Run("Targets", func(t *testing.T) {
+		must := require.New(t)
+		must.ErrorIs(reflectutil.FromMap(nil, convertLimits{}, reflectutil.ConvertOptions{}), reflectutil.ErrConvert)
+		must.ErrorIs(reflectutil.FromMap(nil, new(int), reflectutil.ConvertOptions{}), reflectutil.ErrConvert)
+
+		limits := convertLimits{1, 2}
+		must.NoError(reflectutil.FromMap(map[string]any{"Max": nil, "Other": 1}, &limits, reflectutil.ConvertOptions{}))
+		must.Equal(convertLimits{1, 0}, limits)
+	})
+
+	t.Run("CaseInsensitive", func(t *testing.T) {
+		must := require.New(t)
+		var server convertServer
+		must.NoError(reflectutil.FromMap(map[string]any{
+			"NAME":   "api",
+			"Port":   80,
+			"port":   8080,
+			"limits": map[string]any{"min": 1, "MAX": 2},
+		}, &server, reflectutil.ConvertOptions{}))
+		must.Equal("api", server.Name)
+		must.Equal(8080, server.Port, "exact matches come first")
+		must.Equal(convertLimits{1, 2}, server.Limits)
+
+		must.NoError(reflectutil.FromMap(map[string]any{"Port": 2, "PORT": 1}, &server, reflectutil.ConvertOptions{}))
+		must.Equal(1, server.Port, "the smallest of several keys differing in case is used")
+	})
+}
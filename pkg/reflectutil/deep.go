@@ -0,0 +1,282 @@
+package reflectutil
+
+import (
+	"math"
+	"reflect"
+	"unsafe"
+)
+
+// CopyOptions configures [DeepCopy]. The zero value copies exported fields deeply and unexported ones as they are.
+type CopyOptions struct {
+	// Unexported copies unexported struct fields deeply too, rather than sharing what they point to.
+	Unexported bool
+}
+
+// DeepCopy returns a copy of the value which shares no pointers, slices or maps with it.
+//
+// Values inside v with a Clone method returning their own type are copied by calling it.
+// Pointers, slices and maps reached more than once, including through cycles, are copied once and the copies are shared the same way.
+// Unexported struct fields are copied as they are, sharing what they point to, unless options.Unexported is set.
+// Channels, functions and unsafe pointers are always shared.
+func DeepCopy[T any](v T, options CopyOptions) T {
+	var result T
+	c := copier{options: options, copies: map[copyKey]reflect.Value{}}
+	c.copy(reflect.ValueOf(&result).Elem(), reflect.ValueOf(&v).Elem(), true)
+	return result
+}
+
+type copier struct {
+	options CopyOptions
+	copies  map[copyKey]reflect.Value
+}
+
+// copyKey identifies a pointer, slice or map which was already copied.
+type copyKey struct {
+	ptr unsafe.Pointer
+	len int
+	typ reflect.Type
+}
+
+// copy sets the settable destination to a deep copy of the source, which has the same type.
+// The root is not copied with its Clone method, as that may well call [DeepCopy] with it.
+func (c copier) copy(dst, src reflect.Value, root bool) {
+	if !root && cloneInto(dst, src) {
+		return
+	}
+
+	switch src.Kind() {
+	case reflect.Pointer:
+		if src.IsNil() {
+			dst.SetZero()
+			return
+		}
+		key := copyKey{src.UnsafePointer(), 0, src.Type()}
+		if copied, ok := c.copies[key]; ok {
+			dst.Set(copied)
+			return
+		}
+		copied := reflect.New(src.Type().Elem())
+		c.copies[key] = copied
+		c.copy(copied.Elem(), src.Elem(), false)
+		dst.Set(copied)
+
+	case reflect.Interface:
+		if src.IsNil() {
+			dst.SetZero()
+			return
+		}
+		copied := reflect.New(src.Elem().Type()).Elem()
+		c.copy(copied, src.Elem(), false)
+		dst.Set(copied)
+
+	case reflect.Slice:
+		if src.IsNil() {
+			dst.SetZero()
+			return
+		}
+		key := copyKey{src.UnsafePointer(), src.Len(), src.Type()}
+		if copied, ok := c.copies[key]; ok {
+			dst.Set(copied)
+			return
+		}
+		copied := reflect.MakeSlice(src.Type(), src.Len(), src.Cap())
+		c.copies[key] = copied
+		for i := range src.Len() {
+			c.copy(copied.Index(i), src.Index(i), false)
+		}
+		dst.Set(copied)
+
+	case reflect.Array:
+		for i := range src.Len() {
+			c.copy(dst.Index(i), src.Index(i), false)
+		}
+
+	case reflect.Map:
+		if src.IsNil() {
+			dst.SetZero()
+			return
+		}
+		key := copyKey{src.UnsafePointer(), 0, src.Type()}
+		if copied, ok := c.copies[key]; ok {
+			dst.Set(copied)
+			return
+		}
+		copied := reflect.MakeMapWithSize(src.Type(), src.Len())
+		c.copies[key] = copied
+		for iter := src.MapRange(); iter.Next(); {
+			k := reflect.New(src.Type().Key()).Elem()
+			c.copy(k, iter.Key(), false)
+			v := reflect.New(src.Type().Elem()).Elem()
+			c.copy(v, iter.Value(), false)
+			copied.SetMapIndex(k, v)
+		}
+		dst.Set(copied)
+
+	case reflect.Struct:
+		dst.Set(src)
+		if !src.CanAddr() {
+			addressable := reflect.New(src.Type()).Elem()
+			addressable.Set(src)
+			src = addressable
+		}
+		for i := range src.NumField() {
+			sf := src.Type().Field(i)
+			if !sf.IsExported() && !sf.Anonymous && !c.options.Unexported {
+				continue
+			}
+			c.copy(accessible(dst.Field(i)), accessible(src.Field(i)), false)
+		}
+
+	default:
+		dst.Set(src)
+	}
+}
+
+// cloneInto sets the destination by calling the Clone method of the source, if it has one returning its own type.
+func cloneInto(dst, src reflect.Value) bool {
+	switch src.Kind() {
+	case reflect.Interface:
+		return false
+	case reflect.Pointer, reflect.Map, reflect.Slice:
+		if src.IsNil() {
+			return false
+		}
+	}
+	method := src.MethodByName("Clone")
+	if !method.IsValid() && src.CanAddr() {
+		method = src.Addr().MethodByName("Clone")
+	}
+	if !method.IsValid() {
+		return false
+	}
+	if t := method.Type(); t.NumIn() != 0 || t.NumOut() != 1 || t.Out(0) != src.Type() {
+		return false
+	}
+	dst.Set(method.Call(nil)[0])
+	return true
+}
+
+// accessible returns the addressable value, made settable if it was read from an unexported field.
+func accessible(rv reflect.Value) reflect.Value {
+	if rv.CanSet() {
+		return rv
+	}
+	return reflect.NewAt(rv.Type(), unsafe.Pointer(rv.UnsafeAddr())).Elem()
+}
+
+// EqualOptions configures [DeepEqual]. The zero value compares like [reflect.DeepEqual].
+type EqualOptions struct {
+	// IgnoreTag names a struct tag which leaves fields out of the comparison when set to "-", like `equal:"-"`.
+	IgnoreTag string
+	// FloatTolerance is the largest difference between floats, or the parts of complex numbers, which are still equal.
+	FloatTolerance float64
+	// NilEqualsEmpty makes nil slices and maps equal to empty ones.
+	NilEqualsEmpty bool
+}
+
+// DeepEqual checks whether the values are deeply equal, like [reflect.DeepEqual] with the changes set by the options.
+// Unexported fields are compared too, unless ignored by tag.
+func DeepEqual[T any](a, b T, options EqualOptions) bool {
+	e := equaler{options: options, visited: map[equalVisit]bool{}}
+	return e.equal(reflect.ValueOf(&a).Elem(), reflect.ValueOf(&b).Elem())
+}
+
+type equaler struct {
+	options EqualOptions
+	visited map[equalVisit]bool
+}
+
+// equalVisit identifies a pair of pointers, slices or maps which are already being compared.
+type equalVisit struct {
+	a, b unsafe.Pointer
+	typ  reflect.Type
+}
+
+func (e equaler) equal(a, b reflect.Value) bool {
+	if !a.IsValid() || !b.IsValid() {
+		return a.IsValid() == b.IsValid()
+	}
+	if a.Type() != b.Type() {
+		return false
+	}
+
+	switch a.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Map:
+		if a.IsNil() || b.IsNil() {
+			if e.options.NilEqualsEmpty && a.Kind() != reflect.Pointer {
+				return a.Len() == 0 && b.Len() == 0
+			}
+			return a.IsNil() && b.IsNil()
+		}
+		if a.Kind() != reflect.Pointer && a.Len() != b.Len() {
+			return false
+		}
+		if a.UnsafePointer() == b.UnsafePointer() {
+			return true
+		}
+		// Pairs already being compared are assumed equal, as any difference is found where they are.
+		visit := equalVisit{a.UnsafePointer(), b.UnsafePointer(), a.Type()}
+		if e.visited[visit] {
+			return true
+		}
+		e.visited[visit] = true
+	}
+
+	switch a.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if a.Kind() == reflect.Interface && (a.IsNil() || b.IsNil()) {
+			return a.IsNil() && b.IsNil()
+		}
+		return e.equal(a.Elem(), b.Elem())
+
+	case reflect.Slice, reflect.Array:
+		for i := range a.Len() {
+			if !e.equal(a.Index(i), b.Index(i)) {
+				return false
+			}
+		}
+		return true
+
+	case reflect.Map:
+		for iter := a.MapRange(); iter.Next(); {
+			value := b.MapIndex(iter.Key())
+			if !value.IsValid() || !e.equal(iter.Value(), value) {
+				return false
+			}
+		}
+		return true
+
+	case reflect.Struct:
+		for i := range a.NumField() {
+			if e.options.IgnoreTag != "" && a.Type().Field(i).Tag.Get(e.options.IgnoreTag) == "-" {
+				continue
+			}
+			if !e.equal(a.Field(i), b.Field(i)) {
+				return false
+			}
+		}
+		return true
+
+	case reflect.Func:
+		return a.IsNil() && b.IsNil()
+	case reflect.Chan, reflect.UnsafePointer:
+		return a.Pointer() == b.Pointer()
+	case reflect.Bool:
+		return a.Bool() == b.Bool()
+	case reflect.String:
+		return a.String() == b.String()
+	case reflect.Float32, reflect.Float64:
+		return e.floatEqual(a.Float(), b.Float())
+	case reflect.Complex64, reflect.Complex128:
+		x, y := a.Complex(), b.Complex()
+		return e.floatEqual(real(x), real(y)) && e.floatEqual(imag(x), imag(y))
+	}
+	if a.CanInt() {
+		return a.Int() == b.Int()
+	}
+	return a.Uint() == b.Uint()
+}
+
+func (e equaler) floatEqual(x, y float64) bool {
+	return x == y || math.Abs(x-y) <= e.options.FloatTolerance
+}
@@ -0,0 +1,165 @@
+package reflectutil_test
+
+import (
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/toolvox/utilgo/pkg/reflectutil"
+)
+
+type deepNode struct {
+	Name     string
+	Children []*deepNode
+	Parent   *deepNode
+	Labels   map[string][]int
+	Matrix   [2][]int
+	Value    any
+	Started  time.Time
+	OnChange func()
+	cache    []int
+}
+
+type deepCloner struct {
+	ID     int
+	Clones *int
+}
+
+func (c deepCloner) Clone() deepCloner {
+	*c.Clones++
+	return deepCloner{ID: c.ID + 100, Clones: c.Clones}
+}
+
+type deepPointerCloner struct{ ID int }
+
+func (c *deepPointerCloner) Clone() *deepPointerCloner { return &deepPointerCloner{ID: -c.ID} }
+
+func newDeepTree() *deepNode {
+	root := &deepNode{
+		Name:    "root",
+		Labels:  map[string][]int{"a": {1, 2}},
+		Matrix:  [2][]int{{1}, {2, 3}},
+		Value:   []string{"x"},
+		Started: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC),
+		cache:   []int{9},
+	}
+	child := &deepNode{Name: "child", Parent: root}
+	root.Children = []*deepNode{child, child}
+	return root
+}
+
+func Test_DeepCopy(t *testing.T) {
+	t.Run("Tree", func(t *testing.T) {
+		must := require.New(t)
+		root := newDeepTree()
+		copied := reflectutil.DeepCopy(root, reflectutil.CopyOptions{})
+
+		must.Equal(root.Name, copied.Name)
+		must.Equal(root.Labels, copied.Labels)
+		must.Equal(root.Matrix, copied.Matrix)
+		must.Equal(root.Value, copied.Value)
+		must.Equal(root.Started, copied.Started)
+		must.NotSame(root, copied)
+		must.NotSame(root.Children[0], copied.Children[0])
+		must.Same(copied.Children[0], copied.Children[1], "shared pointers stay shared")
+		must.Same(copied, copied.Children[0].Parent, "cycles are kept")
+
+		copied.Labels["a"][0] = 10
+		copied.Matrix[1][0] = 20
+		copied.Value.([]string)[0] = "y"
+		copied.Children[0].Name = "changed"
+		must.Equal([]int{1, 2}, root.Labels["a"])
+		must.Equal([]int{2, 3}, root.Matrix[1])
+		must.Equal([]string{"x"}, root.Value)
+		must.Equal("child", root.Children[0].Name)
+
+		copied.cache[0] = 10
+		must.Equal(10, root.cache[0], "unexported fields are shared")
+		copied = reflectutil.DeepCopy(root, reflectutil.CopyOptions{Unexported: true})
+		copied.cache[0] = 11
+		must.Equal(10, root.cache[0])
+	})
+
+	t.Run("Clone", func(t *testing.T) {
+		must := require.New(t)
+		var clones int
+		src := struct {
+			Direct  deepCloner
+			Slice   []deepCloner
+			Pointer *deepPointerCloner
+			Value   deepPointerCloner
+		}{deepCloner{1, &clones}, []deepCloner{{2, &clones}}, &deepPointerCloner{3}, deepPointerCloner{4}}
+
+		copied := reflectutil.DeepCopy(src, reflectutil.CopyOptions{})
+		must.Equal(2, clones)
+		must.Equal(101, copied.Direct.ID)
+		must.Equal(102, copied.Slice[0].ID)
+		must.Equal(-3, copied.Pointer.ID)
+		must.Equal(4, copied.Value.ID, "Clone of the pointer does not return the value type")
+
+		root := reflectutil.DeepCopy(deepCloner{5, &clones}, reflectutil.CopyOptions{})
+		must.Equal(5, root.ID, "the root is not cloned with its own method")
+	})
+
+	t.Run("Values", func(t *testing.T) {
+		must := require.New(t)
+		must.Equal(42, reflectutil.DeepCopy(42, reflectutil.CopyOptions{}))
+		must.Nil(reflectutil.DeepCopy[any](nil, reflectutil.CopyOptions{}))
+		must.Nil(reflectutil.DeepCopy[[]int](nil, reflectutil.CopyOptions{}))
+
+		m := map[string]any{"a": map[string]any{"b": []any{1}}}
+		copied := reflectutil.DeepCopy[any](m, reflectutil.CopyOptions{}).(map[string]any)
+		must.Equal(m, copied)
+		copied["a"].(map[string]any)["b"].([]any)[0] = 2
+		must.Equal(1, m["a"].(map[string]any)["b"].([]any)[0])
+	})
+}
+
+func Test_DeepEqual(t *testing.T) {
+	t.Run("Default", func(t *testing.T) {
+		must := require.New(t)
+		must.True(reflectutil.DeepEqual(newDeepTree(), newDeepTree(), reflectutil.EqualOptions{}))
+
+		changed := newDeepTree()
+		changed.Children[1].Name = "other"
+		must.False(reflectutil.DeepEqual(newDeepTree(), changed, reflectutil.EqualOptions{}))
+		changed = newDeepTree()
+		changed.cache = nil
+		must.False(reflectutil.DeepEqual(newDeepTree(), changed, reflectutil.EqualOptions{}))
+
+		for _, pair := range [][2]any{
+			{1, 1}, {"a", "a"}, {nil, nil}, {[]int(nil), []int(nil)}, {map[int]int{1: 2}, map[int]int{1: 2}},
+			{1, 2}, {1, int64(1)}, {nil, 0}, {[]int{}, []int(nil)}, {map[int]int{1: 2}, map[int]int{2: 2}},
+			{math.NaN(), math.NaN()}, {func() {}, func() {}}, {[]any{1.0}, []any{1}},
+		} {
+			must.Equal(reflect.DeepEqual(pair[0], pair[1]), reflectutil.DeepEqual(pair[0], pair[1], reflectutil.EqualOptions{}), "%#v", pair)
+		}
+	})
+
+	t.Run("Options", func(t *testing.T) {
+		must := require.New(t)
+		type measure struct {
+			Value   float64
+			Point   complex128
+			Samples []float32
+			Tags    map[string]string
+			Taken   time.Time `equal:"-"`
+		}
+		a := measure{1, 1 + 1i, []float32{0.5}, nil, time.Now()}
+		b := measure{1.05, 1 + 1.05i, []float32{0.55}, map[string]string{}, time.Now().Add(time.Hour)}
+		must.False(reflectutil.DeepEqual(a, b, reflectutil.EqualOptions{}))
+		options := reflectutil.EqualOptions{IgnoreTag: "equal", FloatTolerance: 0.1, NilEqualsEmpty: true}
+		must.True(reflectutil.DeepEqual(a, b, options))
+
+		b.Samples = append(b.Samples, 0)
+		must.False(reflectutil.DeepEqual(a, b, options))
+		b.Samples, b.Value = a.Samples, 2
+		must.False(reflectutil.DeepEqual(a, b, options))
+		b.Value, b.Tags = a.Value, map[string]string{"a": ""}
+		must.False(reflectutil.DeepEqual(a, b, options))
+		must.False(reflectutil.DeepEqual[*int](nil, new(int), options), "nil pointers are not empty")
+	})
+}
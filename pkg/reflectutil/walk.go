@@ -0,0 +1,151 @@
+package reflectutil
+
+import (
+	"bytes"
+	"cmp"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"unsafe"
+)
+
+// SkipValue is returned by a [Visitor] to not walk into the value it was called with.
+var SkipValue = errors.New("skip value")
+
+// Visitor is called by [Walk] with the path of a value, the struct field holding it, if any, and the value.
+// Returning [SkipValue] skips the fields and elements of the value, and any other error stops the walk.
+type Visitor func(path string, field reflect.StructField, value reflect.Value) error
+
+// Walk calls the visitor for every exported struct field, slice and array element and map value in v, depth first.
+// Paths are the field names, indices and map keys leading to the value joined by ".", like "Servers.0.Password".
+// Map keys are visited in the order of their paths.
+//
+// Pointers and interfaces are walked through, as are embedded structs, whose fields are visited as promoted.
+// A value reached by more than one pointer is only walked the first time.
+//
+// If v is a pointer, the visited values are settable, so the visitor can change them, like redacting secrets.
+// Slice elements are settable either way, as they are shared with v, and so are map values,
+// which are visited as copies that are stored back into their map after they are walked, if they were changed.
+// Walks which change nothing do not write into v, so they can run concurrently.
+func Walk(v any, visitor Visitor) error {
+	w := walker{visitor: visitor, seen: map[copyKey]bool{}}
+	return w.walk("", reflect.ValueOf(v))
+}
+
+type walker struct {
+	visitor Visitor
+	seen    map[copyKey]bool
+}
+
+// walk visits the fields and elements of the value.
+func (w walker) walk(path string, rv reflect.Value) error {
+	switch rv.Kind() {
+	case reflect.Pointer:
+		if rv.IsNil() {
+			return nil
+		}
+		key := copyKey{rv.UnsafePointer(), 0, rv.Type()}
+		if w.seen[key] {
+			return nil
+		}
+		w.seen[key] = true
+		return w.walk(path, rv.Elem())
+
+	case reflect.Interface:
+		if rv.IsNil() {
+			return nil
+		}
+		if !rv.CanSet() {
+			return w.walk(path, rv.Elem())
+		}
+		// The value in an interface is not settable, so it is walked as a copy which is stored back if changed.
+		elem, changed := settableCopy(rv.Elem())
+		if err := w.walk(path, elem); err != nil {
+			return err
+		}
+		if changed() {
+			rv.Set(elem)
+		}
+
+	case reflect.Struct:
+		for i := range rv.NumField() {
+			sf := rv.Type().Field(i)
+			fv := rv.Field(i)
+			if sf.Anonymous && indirect(sf.Type).Kind() == reflect.Struct {
+				if !sf.IsExported() {
+					if !rv.CanAddr() {
+						continue
+					}
+					fv = accessible(fv)
+				}
+				if err := w.walk(path, fv); err != nil {
+					return err
+				}
+				continue
+			}
+			if !sf.IsExported() {
+				continue
+			}
+			if err := w.visit(joinPath(path, sf.Name), sf, fv); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Slice, reflect.Array:
+		for i := range rv.Len() {
+			if err := w.visit(joinPath(path, strconv.Itoa(i)), reflect.StructField{}, rv.Index(i)); err != nil {
+				return err
+			}
+		}
+
+	case reflect.Map:
+		keys := rv.MapKeys()
+		slices.SortFunc(keys, func(a, b reflect.Value) int { return cmp.Compare(fmt.Sprint(a), fmt.Sprint(b)) })
+		for _, key := range keys {
+			value, changed := settableCopy(rv.MapIndex(key))
+			if err := w.visit(joinPath(path, fmt.Sprint(key)), reflect.StructField{}, value); err != nil {
+				return err
+			}
+			if changed() {
+				rv.SetMapIndex(key, value)
+			}
+		}
+	}
+	return nil
+}
+
+// visit calls the visitor with the value, then walks into it unless skipped.
+func (w walker) visit(path string, field reflect.StructField, value reflect.Value) error {
+	err := w.visitor(path, field, value)
+	if errors.Is(err, SkipValue) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	return w.walk(path, value)
+}
+
+// settableCopy returns a settable copy of the value, and a function checking whether the copy has changed since.
+// Only changed copies are stored back, so walks which change nothing do not write into the maps of v.
+func settableCopy(rv reflect.Value) (reflect.Value, func() bool) {
+	value := reflect.New(rv.Type()).Elem()
+	value.Set(rv)
+	snapshot := reflect.New(rv.Type()).Elem()
+	snapshot.Set(rv)
+	return value, func() bool { return !bytes.Equal(bitsOf(value), bitsOf(snapshot)) }
+}
+
+// bitsOf returns the memory of the addressable value.
+func bitsOf(rv reflect.Value) []byte {
+	return unsafe.Slice((*byte)(rv.Addr().UnsafePointer()), rv.Type().Size())
+}
+
+func indirect(t reflect.Type) reflect.Type {
+	if t.Kind() == reflect.Pointer {
+		return t.Elem()
+	}
+	return t
+}
@@ -0,0 +1,173 @@
+package reflectutil_test
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/toolvox/utilgo/pkg/reflectutil"
+)
+
+type walkCredentials struct {
+	User     string
+	Password string `log:"secret"`
+}
+
+type walkConfig struct {
+	walkCredentials
+	Name      string
+	Replicas  []walkCredentials
+	Databases map[string]walkCredentials
+	Tokens    map[string]string `log:"secret"`
+	Next      *walkConfig
+	Extra     any
+	internal  string
+}
+
+func newWalkConfig() *walkConfig {
+	config := &walkConfig{
+		walkCredentials: walkCredentials{"admin", "hunter2"},
+		Name:            "app",
+		Replicas:        []walkCredentials{{"r", "pw"}},
+		Databases:       map[string]walkCredentials{"b": {"u", "p"}, "a": {"v", "q"}},
+		Tokens:          map[string]string{"api": "t"},
+		Extra:           []int{1},
+		internal:        "internal",
+	}
+	config.Next = config
+	return config
+}
+
+func Test_Walk(t *testing.T) {
+	t.Run("Paths", func(t *testing.T) {
+		must := require.New(t)
+		var paths []string
+		must.NoError(reflectutil.Walk(newWalkConfig(), func(path string, field reflect.StructField, value reflect.Value) error {
+			paths = append(paths, fmt.Sprintf("%s:%s", path, field.Name))
+			return nil
+		}))
+		must.Equal([]string{
+			"User:User", "Password:Password", "Name:Name",
+			"Replicas:Replicas", "Replicas.0:", "Replicas.0.User:User", "Replicas.0.Password:Password",
+			"Databases:Databases", "Databases.a:", "Databases.a.User:User", "Databases.a.Password:Password",
+			"Databases.b:", "Databases.b.User:User", "Databases.b.Password:Password",
+			"Tokens:Tokens", "Tokens.api:",
+			"Next:Next", "Extra:Extra", "Extra.0:",
+		}, paths)
+	})
+
+	t.Run("Redact", func(t *testing.T) {
+		must := require.New(t)
+		config := newWalkConfig()
+		must.NoError(reflectutil.Walk(config, func(path string, field reflect.StructField, value reflect.Value) error {
+			if field.Tag.Get("log") != "secret" {
+				return nil
+			}
+			value.SetZero()
+			return reflectutil.SkipValue
+		}))
+		must.Equal(walkCredentials{"admin", ""}, config.walkCredentials)
+		must.Equal([]walkCredentials{{"r", ""}}, config.Replicas)
+		must.Equal(map[string]walkCredentials{"b": {"u", ""}, "a": {"v", ""}}, config.Databases)
+		must.Nil(config.Tokens)
+		must.Equal("internal", config.internal)
+	})
+
+	t.Run("NestedMaps", func(t *testing.T) {
+		must := require.New(t)
+		config := map[string]any{
+			"name": "app",
+			"db":   map[string]any{"user": "u", "password": "p", "replicas": []any{map[string]any{"password": "r"}}},
+			"ldap": walkCredentials{"l", "s"},
+		}
+		must.NoError(reflectutil.Walk(&config, func(path string, _ reflect.StructField, value reflect.Value) error {
+			if strings.HasSuffix(strings.ToLower(path), "password") {
+				value.Set(reflect.ValueOf("***"))
+			}
+			return nil
+		}))
+		must.Equal(map[string]any{
+			"name": "app",
+			"db":   map[string]any{"user": "u", "password": "***", "replicas": []any{map[string]any{"password": "***"}}},
+			"ldap": walkCredentials{"l", "***"},
+		}, config)
+	})
+
+	t.Run("Concurrent", func(t *testing.T) {
+		config := map[string]any{
+			"db":   map[string]any{"password": "p", "hosts": []any{"a", "b"}},
+			"ldap": walkCredentials{"l", "s"},
+		}
+		var wg sync.WaitGroup
+		for range 2 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for range 100 {
+					var paths int
+					require.NoError(t, reflectutil.Walk(&config, func(string, reflect.StructField, reflect.Value) error {
+						paths++
+						return nil
+					}))
+					require.Equal(t, 8, paths)
+				}
+			}()
+		}
+		wg.Wait()
+	})
+
+	t.Run("Values", func(t *testing.T) {
+		must := require.New(t)
+		config := newWalkConfig()
+		config.Next = nil
+		settable := map[string]bool{}
+		must.NoError(reflectutil.Walk(*config, func(path string, _ reflect.StructField, value reflect.Value) error {
+			settable[path] = value.CanSet()
+			return nil
+		}))
+		must.NotContains(settable, "User", "unexported embedded structs are not reachable by value")
+		must.False(settable["Name"])
+		must.True(settable["Replicas.0.Password"], "slices share their elements")
+		must.True(settable["Databases.a.Password"], "maps share their values")
+
+		must.NoError(reflectutil.Walk(nil, nil))
+		must.NoError(reflectutil.Walk(42, nil))
+	})
+
+	t.Run("Errors", func(t *testing.T) {
+		must := require.New(t)
+		errStop := errors.New("stop")
+		var visited int
+		err := reflectutil.Walk(newWalkConfig(), func(path string, _ reflect.StructField, _ reflect.Value) error {
+			if visited++; path == "Replicas.0" {
+				return errStop
+			}
+			return nil
+		})
+		must.ErrorIs(err, errStop)
+		must.Equal(5, visited)
+	})
+}
+
+func ExampleWalk() {
+	type Database struct {
+		User     string
+		Password string `log:"secret"`
+	}
+	config := map[string]Database{"main": {"app", "hunter2"}}
+
+	redacted := reflectutil.DeepCopy(config, reflectutil.CopyOptions{})
+	_ = reflectutil.Walk(&redacted, func(path string, field reflect.StructField, value reflect.Value) error {
+		if field.Tag.Get("log") == "secret" {
+			value.SetString("***")
+		}
+		return nil
+	})
+	fmt.Println(config["main"].Password, redacted["main"].Password)
+	// Output: hunter2 ***
+}
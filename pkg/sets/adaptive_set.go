@@ -0,0 +1,250 @@
+package sets
+
+import (
+	"encoding/json"
+	"iter"
+	"maps"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/toolvox/utilgo/api"
+)
+
+// DefaultAdaptiveThreshold is the size past which an [AdaptiveSet] with no Threshold promotes to a [Set].
+const DefaultAdaptiveThreshold = 32
+
+// AdaptiveSet represents a set of elements of type C that starts as a [TinySet],
+// promotes itself to a map-backed [Set] once it grows past its threshold,
+// and demotes itself back once it shrinks to half of it.
+//
+// The zero value is an empty set with the [DefaultAdaptiveThreshold].
+type AdaptiveSet[C comparable] struct {
+	// Threshold is the size past which the set promotes to a [Set]. Zero means [DefaultAdaptiveThreshold].
+	Threshold int
+
+	tiny TinySet[C]
+	set  Set[C]
+}
+
+// NewAdaptiveSet initializes a new [AdaptiveSet] with the given elements, ensuring uniqueness.
+func NewAdaptiveSet[C comparable](elements ...C) *AdaptiveSet[C] {
+	return NewAdaptiveSetWithThreshold(0, elements...)
+}
+
+// NewAdaptiveSetWithThreshold initializes a new [AdaptiveSet] with the given threshold and elements, ensuring uniqueness.
+func NewAdaptiveSetWithThreshold[C comparable](threshold int, elements ...C) *AdaptiveSet[C] {
+	result := &AdaptiveSet[C]{Threshold: threshold}
+	result.Add(elements...)
+	return result
+}
+
+// IsPromoted reports whether the [AdaptiveSet] is currently backed by a [Set].
+func (set AdaptiveSet[C]) IsPromoted() bool { return set.set != nil }
+
+// String returns the string representation of the [AdaptiveSet], in sorted order like a [Set].
+func (set AdaptiveSet[C]) String() string { return formatElements(sortElements(set.Elements())) }
+
+// Len counts the elements in the [AdaptiveSet].
+func (set AdaptiveSet[C]) Len() int {
+	if set.IsPromoted() {
+		return len(set.set)
+	}
+	return len(set.tiny)
+}
+
+// Elements returns the unique elements of the [AdaptiveSet].
+func (set AdaptiveSet[C]) Elements() []C {
+	if set.IsPromoted() {
+		return set.set.Elements()
+	}
+	return append(make([]C, 0, len(set.tiny)), set.tiny...)
+}
+
+// Add unique elements to the [AdaptiveSet], promoting it if it grows past its threshold.
+// Repeated elements will be discarded.
+func (set *AdaptiveSet[C]) Add(elements ...C) {
+	if set.IsPromoted() {
+		set.set.Add(elements...)
+		return
+	}
+	for i, e := range elements {
+		if set.tiny.Contains(e) {
+			continue
+		}
+		if len(set.tiny) >= set.threshold() {
+			set.promote()
+			set.set.Add(elements[i:]...)
+			return
+		}
+		set.tiny = append(set.tiny, e)
+	}
+}
+
+// Contains checked whether all elements are in the [AdaptiveSet].
+func (set AdaptiveSet[C]) Contains(elements ...C) bool {
+	if set.IsPromoted() {
+		return set.set.Contains(elements...)
+	}
+	return set.tiny.Contains(elements...)
+}
+
+// Remove any existing elements from the [AdaptiveSet], demoting it if it shrinks to half its threshold.
+func (set *AdaptiveSet[C]) Remove(elements ...C) {
+	if !set.IsPromoted() {
+		set.tiny.Remove(elements...)
+		return
+	}
+	set.set.Remove(elements...)
+	if len(set.set) <= set.threshold()/2 {
+		set.demote()
+	}
+}
+
+// Union combines two sets into a new one containing elements from both.
+func (set AdaptiveSet[C]) Union(other *AdaptiveSet[C]) *AdaptiveSet[C] {
+	return set.UnionWith(other.Elements()...)
+}
+
+// UnionWith adds multiple elements to a copy of the set and returns the resulting set.
+func (set AdaptiveSet[C]) UnionWith(elements ...C) *AdaptiveSet[C] {
+	result := NewAdaptiveSetWithThreshold(set.Threshold, set.Elements()...)
+	result.Add(elements...)
+	return result
+}
+
+// Intersection creates a set of elements common to both sets.
+func (set AdaptiveSet[C]) Intersection(other *AdaptiveSet[C]) *AdaptiveSet[C] {
+	small, large := set, *other
+	if small.Len() > large.Len() {
+		small, large = large, small
+	}
+	result := &AdaptiveSet[C]{Threshold: set.Threshold}
+	for _, e := range small.Elements() {
+		if large.Contains(e) {
+			result.Add(e)
+		}
+	}
+	return result
+}
+
+// IntersectionWith forms a set from common elements of the set and the provided elements.
+func (set AdaptiveSet[C]) IntersectionWith(elements ...C) *AdaptiveSet[C] {
+	return set.Intersection(NewAdaptiveSetWithThreshold(set.Threshold, elements...))
+}
+
+// Difference creates a set of elements in the first set but not in the second.
+func (set AdaptiveSet[C]) Difference(other *AdaptiveSet[C]) *AdaptiveSet[C] {
+	result := &AdaptiveSet[C]{Threshold: set.Threshold}
+	for _, e := range set.Elements() {
+		if !other.Contains(e) {
+			result.Add(e)
+		}
+	}
+	return result
+}
+
+// DifferenceWith creates a set of the elements of the set that are not among the provided elements.
+func (set AdaptiveSet[C]) DifferenceWith(elements ...C) *AdaptiveSet[C] {
+	return set.Difference(NewAdaptiveSetWithThreshold(set.Threshold, elements...))
+}
+
+// ThreeWay splits elements into three sets: common, only in the first set, and only in the second set.
+func (set AdaptiveSet[C]) ThreeWay(other *AdaptiveSet[C]) [3]*AdaptiveSet[C] {
+	return [3]*AdaptiveSet[C]{
+		set.Intersection(other),
+		set.Difference(other),
+		other.Difference(&set),
+	}
+}
+
+// SymmetricDifference creates a set of elements in exactly one of the sets.
+func (set AdaptiveSet[C]) SymmetricDifference(other *AdaptiveSet[C]) *AdaptiveSet[C] {
+	return set.Difference(other).Union(other.Difference(&set))
+}
+
+// All iterates over the elements of the [AdaptiveSet].
+func (set AdaptiveSet[C]) All() iter.Seq[C] {
+	if set.IsPromoted() {
+		return set.set.All()
+	}
+	return set.tiny.All()
+}
+
+// Clear removes all elements from the [AdaptiveSet], demoting it.
+func (set *AdaptiveSet[C]) Clear() {
+	set.tiny, set.set = nil, nil
+}
+
+// Clone returns a copy of the [AdaptiveSet], with the same threshold.
+func (set AdaptiveSet[C]) Clone() api.Set[C] {
+	return &AdaptiveSet[C]{Threshold: set.Threshold, tiny: slices.Clone(set.tiny), set: maps.Clone(set.set)}
+}
+
+// ContainsAny checks whether any of the elements is in the [AdaptiveSet].
+func (set AdaptiveSet[C]) ContainsAny(elements ...C) bool { return containsAny(set, elements) }
+
+// IsSubset checks whether all elements of the [AdaptiveSet] are in the other set.
+func (set AdaptiveSet[C]) IsSubset(other api.ReadOnlySet[C]) bool { return isSubset(set, other) }
+
+// IsSuperset checks whether all elements of the other set are in the [AdaptiveSet].
+func (set AdaptiveSet[C]) IsSuperset(other api.ReadOnlySet[C]) bool { return isSuperset(set, other) }
+
+// Equal checks whether the [AdaptiveSet] and the other set have the same elements.
+func (set AdaptiveSet[C]) Equal(other api.ReadOnlySet[C]) bool { return isEqual(set, other) }
+
+// Disjoint checks whether the [AdaptiveSet] and the other set have no elements in common.
+func (set AdaptiveSet[C]) Disjoint(other api.ReadOnlySet[C]) bool { return isDisjoint(set, other) }
+
+// MarshalJSON converts the [AdaptiveSet] to a JSON array, in sorted order like a [Set].
+func (set AdaptiveSet[C]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(sortElements(set.Elements()))
+}
+
+// UnmarshalJSON converts the JSON []byte to an [AdaptiveSet], keeping its threshold.
+func (set *AdaptiveSet[C]) UnmarshalJSON(data []byte) error {
+	var slice []C
+	if err := json.Unmarshal(data, &slice); err != nil {
+		return err
+	}
+	*set = *NewAdaptiveSetWithThreshold(set.Threshold, slice...)
+	return nil
+}
+
+// MarshalYAML converts the [AdaptiveSet] to a YAML array, in sorted order like a [Set].
+func (set AdaptiveSet[C]) MarshalYAML() (interface{}, error) {
+	return sortElements(set.Elements()), nil
+}
+
+// UnmarshalYAML converts the YAML [yaml.Node] (representing a YAML list) to an [AdaptiveSet], keeping its threshold.
+func (set *AdaptiveSet[C]) UnmarshalYAML(value *yaml.Node) error {
+	var slice []C
+	if err := value.Decode(&slice); err != nil {
+		return err
+	}
+	*set = *NewAdaptiveSetWithThreshold(set.Threshold, slice...)
+	return nil
+}
+
+func (set AdaptiveSet[C]) threshold() int {
+	if set.Threshold <= 0 {
+		return DefaultAdaptiveThreshold
+	}
+	return set.Threshold
+}
+
+// promote moves the elements to a [Set], sized for twice the threshold so it does not grow again right away.
+func (set *AdaptiveSet[C]) promote() {
+	set.set = make(Set[C], 2*set.threshold())
+	set.set.Add(set.tiny...)
+	set.tiny = nil
+}
+
+// demote moves the elements back to a [TinySet].
+func (set *AdaptiveSet[C]) demote() {
+	set.tiny = make(TinySet[C], 0, set.threshold())
+	for e := range set.set {
+		set.tiny = append(set.tiny, e)
+	}
+	set.set = nil
+}
@@ -0,0 +1,125 @@
+package sets_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/toolvox/utilgo/pkg/sets"
+	"github.com/toolvox/utilgo/test"
+	test_sets "github.com/toolvox/utilgo/test/sets_test"
+)
+
+func Test_AdaptiveSet(t *testing.T) {
+	t.Run("int", func(t *testing.T) {
+		test_sets.Run_Test_Set(t,
+			test.TestConstructorFor[*sets.AdaptiveSet[int]]{
+				NewFunc: func() *sets.AdaptiveSet[int] {
+					return sets.NewAdaptiveSet[int]()
+				},
+			},
+			test.TestDataFor[int]{
+				ElementFunc: func(i int) int { return i },
+			},
+			10_000,
+		)
+	})
+	t.Run("string", func(t *testing.T) {
+		test_sets.Run_Test_Set(t,
+			test.TestConstructorFor[*sets.AdaptiveSet[string]]{
+				NewFunc: func() *sets.AdaptiveSet[string] {
+					return &sets.AdaptiveSet[string]{Threshold: 4}
+				},
+			},
+			test.TestDataFor[string]{
+				ElementFunc: func(i int) string { return fmt.Sprint(i) },
+			},
+			1_000,
+		)
+	})
+
+	t.Run("Promotion", func(t *testing.T) {
+		must := require.New(t)
+		testSet := sets.NewAdaptiveSetWithThreshold(4, 1, 2, 3, 4)
+		must.False(testSet.IsPromoted())
+		testSet.Add(4, 3)
+		must.False(testSet.IsPromoted())
+		testSet.Add(5, 6)
+		must.True(testSet.IsPromoted())
+		must.Equal(6, testSet.Len())
+		must.True(testSet.Contains(1, 2, 3, 4, 5, 6))
+
+		testSet.Remove(1, 2, 3)
+		must.True(testSet.IsPromoted())
+		testSet.Remove(4)
+		must.False(testSet.IsPromoted())
+		must.ElementsMatch([]int{5, 6}, testSet.Elements())
+		must.True(testSet.Contains(5, 6))
+		must.False(testSet.Contains(4))
+
+		var zero sets.AdaptiveSet[int]
+		for i := range sets.DefaultAdaptiveThreshold {
+			zero.Add(i)
+		}
+		must.False(zero.IsPromoted())
+		zero.Add(sets.DefaultAdaptiveThreshold)
+		must.True(zero.IsPromoted())
+	})
+
+	t.Run("Operations", func(t *testing.T) {
+		for _, threshold := range []int{1, 2, 100} {
+			t.Run(fmt.Sprint("threshold=", threshold), func(t *testing.T) {
+				must := require.New(t)
+				a := sets.NewAdaptiveSetWithThreshold(threshold, 4, 1, 3)
+				b := sets.NewAdaptiveSetWithThreshold(threshold, 3, 2, 4, 8)
+				must.ElementsMatch([]int{1, 2, 3, 4, 8}, a.Union(b).Elements())
+				must.ElementsMatch([]int{0, 1, 3, 4}, a.UnionWith(0, 1).Elements())
+				must.ElementsMatch([]int{3, 4}, a.Intersection(b).Elements())
+				must.ElementsMatch([]int{1, 3}, a.IntersectionWith(3, 1, 7).Elements())
+				must.ElementsMatch([]int{1}, a.Difference(b).Elements())
+				must.ElementsMatch([]int{3, 4}, a.DifferenceWith(1).Elements())
+				must.Equal(threshold, a.Union(b).Threshold)
+
+				threeWay := a.ThreeWay(b)
+				must.ElementsMatch([]int{3, 4}, threeWay[0].Elements())
+				must.ElementsMatch([]int{1}, threeWay[1].Elements())
+				must.ElementsMatch([]int{2, 8}, threeWay[2].Elements())
+				must.ElementsMatch([]int{1, 2, 8}, a.SymmetricDifference(b).Elements())
+			})
+		}
+	})
+}
+
+func Test_AdaptiveSet_Encoding(t *testing.T) {
+	must := require.New(t)
+	testSet := sets.NewAdaptiveSet("hello", "goodbye", "salute")
+
+	bs, err := json.Marshal(testSet)
+	must.NoError(err)
+	var jsonSet sets.AdaptiveSet[string]
+	must.NoError(json.Unmarshal(bs, &jsonSet))
+	must.ElementsMatch(testSet.Elements(), jsonSet.Elements())
+
+	jsonSet = sets.AdaptiveSet[string]{Threshold: 1}
+	must.NoError(json.Unmarshal([]byte(`["a","b","a"]`), &jsonSet))
+	must.Equal(1, jsonSet.Threshold)
+	must.True(jsonSet.IsPromoted())
+	must.ElementsMatch([]string{"a", "b"}, jsonSet.Elements())
+
+	promoted := sets.NewAdaptiveSetWithThreshold(2, 5, 1, 4, 2)
+	must.True(promoted.IsPromoted())
+	must.Equal("{ 1, 2, 4, 5 }", promoted.String())
+	bs, err = json.Marshal(promoted)
+	must.NoError(err)
+	must.Equal(`[1,2,4,5]`, string(bs))
+
+	bs, err = yaml.Marshal(testSet)
+	must.NoError(err)
+	must.Equal("- goodbye\n- hello\n- salute\n", string(bs))
+	var yamlSet *sets.AdaptiveSet[string]
+	must.NoError(yaml.Unmarshal(bs, &yamlSet))
+	must.ElementsMatch(testSet.Elements(), yamlSet.Elements())
+}
@@ -0,0 +1,126 @@
+package sets
+
+import (
+	"iter"
+	"slices"
+
+	"github.com/toolvox/utilgo/api"
+)
+
+// Union creates a [Set] of the elements of all sets, of any implementation, mutable or not.
+func Union[C comparable](sets ...api.ReadOnlySet[C]) Set[C] {
+	result := NewSet[C]()
+	for _, set := range sets {
+		for e := range allOf(set) {
+			result[e] = U
+		}
+	}
+	return result
+}
+
+// Intersection creates a [Set] of the elements of the first set that are in all other sets, of any implementation.
+func Intersection[C comparable](first api.ReadOnlySet[C], others ...api.ReadOnlySet[C]) Set[C] {
+	result := NewSet[C]()
+outer:
+	for e := range allOf(first) {
+		for _, other := range others {
+			if !other.Contains(e) {
+				continue outer
+			}
+		}
+		result[e] = U
+	}
+	return result
+}
+
+// Difference creates a [Set] of the elements of the first set that are in none of the other sets, of any implementation.
+func Difference[C comparable](first api.ReadOnlySet[C], others ...api.ReadOnlySet[C]) Set[C] {
+	result := NewSet[C]()
+outer:
+	for e := range allOf(first) {
+		for _, other := range others {
+			if other.Contains(e) {
+				continue outer
+			}
+		}
+		result[e] = U
+	}
+	return result
+}
+
+// SymmetricDifference creates a [Set] of the elements in exactly one of the sets, of any implementation.
+func SymmetricDifference[C comparable](a, b api.ReadOnlySet[C]) Set[C] {
+	blr := ThreeWay(a, b)
+	return blr[1].Union(blr[2])
+}
+
+// ThreeWay splits elements of sets of any implementation into three [Set]s: common, only in the first set, and only in the second set.
+func ThreeWay[C comparable](a, b api.ReadOnlySet[C]) [3]Set[C] {
+	blr := [3]Set[C]{NewSet[C](), NewSet[C](), NewSet[C]()}
+	for e := range allOf(a) {
+		if b.Contains(e) {
+			blr[0][e] = U
+		} else {
+			blr[1][e] = U
+		}
+	}
+	for e := range allOf(b) {
+		if !a.Contains(e) {
+			blr[2][e] = U
+		}
+	}
+	return blr
+}
+
+// allOf iterates over the elements of the set, without copying them if it can.
+func allOf[C comparable](set api.ReadOnlySet[C]) iter.Seq[C] {
+	if iterable, ok := set.(interface{ All() iter.Seq[C] }); ok {
+		return iterable.All()
+	}
+	return slices.Values(set.Elements())
+}
+
+// readableSet is the read-only part of [api.Set], which the predicates of every implementation share.
+type readableSet[C comparable] interface {
+	Len() int
+	Contains(elements ...C) bool
+	All() iter.Seq[C]
+}
+
+func containsAny[C comparable](set readableSet[C], elements []C) bool {
+	for _, e := range elements {
+		if set.Contains(e) {
+			return true
+		}
+	}
+	return false
+}
+
+func isSubset[C comparable](set readableSet[C], other api.ReadOnlySet[C]) bool {
+	if set.Len() > other.Len() {
+		return false
+	}
+	for e := range set.All() {
+		if !other.Contains(e) {
+			return false
+		}
+	}
+	return true
+}
+
+func isSuperset[C comparable](set readableSet[C], other api.ReadOnlySet[C]) bool {
+	return set.Len() >= other.Len() && set.Contains(other.Elements()...)
+}
+
+func isEqual[C comparable](set readableSet[C], other api.ReadOnlySet[C]) bool {
+	return set.Len() == other.Len() && isSubset(set, other)
+}
+
+func isDisjoint[C comparable](set readableSet[C], other api.ReadOnlySet[C]) bool {
+	for e := range set.All() {
+		if other.Contains(e) {
+			return false
+		}
+	}
+	return true
+}
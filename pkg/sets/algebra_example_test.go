@@ -0,0 +1,33 @@
+package sets_test
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/toolvox/utilgo/pkg/sets"
+)
+
+func ExampleUnion() {
+	tinySet := sets.NewTinySet(1, 2, 3)
+	mapSet := sets.NewSet(3, 4)
+	sortedSet := sets.NewSortedSet(5)
+
+	union := sets.Union[int](tinySet, mapSet, sortedSet)
+	fmt.Println("Union:", slices.Sorted(union.All()))
+
+	intersection := sets.Intersection[int](tinySet, mapSet)
+	fmt.Println("Intersection:", slices.Sorted(intersection.All()))
+
+	symmetric := sets.SymmetricDifference[int](tinySet, mapSet)
+	fmt.Println("SymmetricDifference:", slices.Sorted(symmetric.All()))
+
+	fmt.Println("Subset:", tinySet.IsSubset(union))
+	fmt.Println("Disjoint:", mapSet.Disjoint(sortedSet))
+
+	// Output:
+	// Union: [1 2 3 4 5]
+	// Intersection: [3]
+	// SymmetricDifference: [1 2 4]
+	// Subset: true
+	// Disjoint: true
+}
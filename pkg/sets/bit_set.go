@@ -0,0 +1,429 @@
+package sets
+
+import (
+	"encoding/json"
+	"fmt"
+	"iter"
+	"math/bits"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/toolvox/utilgo/api"
+	"github.com/toolvox/utilgo/pkg/errs"
+	"github.com/toolvox/utilgo/pkg/mathutil"
+)
+
+// wordBits is the number of elements a [BitSet] word holds.
+const wordBits = 64
+
+// BitSet represents a set of non-negative integers of type I as bits packed in words.
+// It is meant for small domains, as its size grows with its largest element.
+//
+// Adding a negative element panics.
+type BitSet[I api.Integer] struct {
+	// Compact marshals the set as a string of ranges, like "1-3,7", instead of a list.
+	Compact bool
+
+	words []uint64
+}
+
+// NewBitSet initializes a new [BitSet] with the given elements.
+func NewBitSet[I api.Integer](elements ...I) *BitSet[I] {
+	result := &BitSet[I]{}
+	result.Add(elements...)
+	return result
+}
+
+// NewBitSetFromInterval initializes a new [BitSet] with the elements of the interval.
+// It returns an error if the interval is unbounded or holds negative values.
+func NewBitSetFromInterval[I api.Integer](interval api.Interval[I]) (*BitSet[I], error) {
+	result := &BitSet[I]{}
+	if interval.IsEmpty() {
+		return result, nil
+	}
+	if interval.IsUnbounded() {
+		return nil, errs.Newf("bitset from interval %s: interval is unbounded", interval)
+	}
+	if interval.Min() < 0 {
+		return nil, errs.Newf("bitset from interval %s: interval has negative values", interval)
+	}
+	for _, subInt := range interval.Intervals() {
+		first, last := subInt.Min(), subInt.Max()
+		if subInt.MinBound() == api.BOUND_OPEN {
+			first++
+		}
+		if subInt.MaxBound() == api.BOUND_OPEN {
+			if last == 0 {
+				continue
+			}
+			last--
+		}
+		result.AddRange(first, last)
+	}
+	return result, nil
+}
+
+// String returns the string representation of the [BitSet], in ascending order.
+func (set BitSet[I]) String() string { return formatElements(set.Elements()) }
+
+// Len counts the elements in the [BitSet].
+func (set BitSet[I]) Len() int {
+	var count int
+	for _, w := range set.words {
+		count += bits.OnesCount64(w)
+	}
+	return count
+}
+
+// Elements returns the elements of the [BitSet], in ascending order.
+func (set BitSet[I]) Elements() []I {
+	elements := make([]I, 0, set.Len())
+	for i, w := range set.words {
+		for w != 0 {
+			elements = append(elements, I(i*wordBits+bits.TrailingZeros64(w)))
+			w &= w - 1
+		}
+	}
+	return elements
+}
+
+// Add elements to the [BitSet].
+func (set *BitSet[I]) Add(elements ...I) {
+	for _, e := range elements {
+		word, bit := set.locate(e)
+		set.grow(word)
+		set.words[word] |= bit
+	}
+}
+
+// AddRange adds the elements from min to max, inclusive, to the [BitSet].
+func (set *BitSet[I]) AddRange(min, max I) {
+	if max < min {
+		return
+	}
+	first, _ := set.locate(min)
+	last, _ := set.locate(max)
+	set.grow(last)
+	for word := first; word <= last; word++ {
+		mask := ^uint64(0)
+		if word == first {
+			mask &= ^uint64(0) << (uint64(min) % wordBits)
+		}
+		if word == last {
+			mask &= ^uint64(0) >> (wordBits - 1 - uint64(max)%wordBits)
+		}
+		set.words[word] |= mask
+	}
+}
+
+// Contains checked whether all elements are in the [BitSet].
+func (set BitSet[I]) Contains(elements ...I) bool {
+	for _, e := range elements {
+		if e < 0 {
+			return false
+		}
+		word, bit := set.locate(e)
+		if word >= len(set.words) || set.words[word]&bit == 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Remove any existing elements from the [BitSet].
+func (set *BitSet[I]) Remove(elements ...I) {
+	for _, e := range elements {
+		if e < 0 {
+			continue
+		}
+		if word, bit := set.locate(e); word < len(set.words) {
+			set.words[word] &^= bit
+		}
+	}
+	set.trim()
+}
+
+// NextSet returns the smallest element greater than or equal to from, and false if there is none.
+//
+// Iterate over the elements with the loop below, which stops before e + 1 wraps around at the largest I:
+//
+//	for e, ok := set.NextSet(0); ok; e, ok = set.NextSet(e + 1) {
+//		...
+//		if e == mathutil.MaxValue[I]() {
+//			break
+//		}
+//	}
+func (set BitSet[I]) NextSet(from I) (I, bool) {
+	if from < 0 {
+		from = 0
+	}
+	word, _ := set.locate(from)
+	if word >= len(set.words) {
+		return 0, false
+	}
+	w := set.words[word] & (^uint64(0) << (uint64(from) % wordBits))
+	for {
+		if w != 0 {
+			return I(word*wordBits + bits.TrailingZeros64(w)), true
+		}
+		if word++; word == len(set.words) {
+			return 0, false
+		}
+		w = set.words[word]
+	}
+}
+
+// Interval returns the elements of the [BitSet] as an interval, where each run of elements is a sub-interval.
+func (set BitSet[I]) Interval() api.Interval[I] {
+	var runs []api.Interval[I]
+	set.runs(func(min, max I) {
+		runs = append(runs, mathutil.NewClosed(min, max))
+	})
+	return mathutil.RawMerged(runs...)
+}
+
+// Union combines two sets into a new one containing elements from both.
+func (set BitSet[I]) Union(other *BitSet[I]) *BitSet[I] {
+	result := &BitSet[I]{words: make([]uint64, max(len(set.words), len(other.words)))}
+	copy(result.words, set.words)
+	for i, w := range other.words {
+		result.words[i] |= w
+	}
+	return result
+}
+
+// UnionWith adds multiple elements to a copy of the set and returns the resulting set.
+func (set BitSet[I]) UnionWith(elements ...I) *BitSet[I] {
+	return set.Union(NewBitSet(elements...))
+}
+
+// Intersection creates a set of elements common to both sets.
+func (set BitSet[I]) Intersection(other *BitSet[I]) *BitSet[I] {
+	result := &BitSet[I]{words: make([]uint64, min(len(set.words), len(other.words)))}
+	for i := range result.words {
+		result.words[i] = set.words[i] & other.words[i]
+	}
+	result.trim()
+	return result
+}
+
+// IntersectionWith forms a set from common elements of the set and the provided elements.
+func (set BitSet[I]) IntersectionWith(elements ...I) *BitSet[I] {
+	other := &BitSet[I]{}
+	for _, e := range elements {
+		if e >= 0 {
+			other.Add(e)
+		}
+	}
+	return set.Intersection(other)
+}
+
+// Difference creates a set of elements in the first set but not in the second.
+func (set BitSet[I]) Difference(other *BitSet[I]) *BitSet[I] {
+	result := &BitSet[I]{words: append([]uint64(nil), set.words...)}
+	for i := range min(len(result.words), len(other.words)) {
+		result.words[i] &^= other.words[i]
+	}
+	result.trim()
+	return result
+}
+
+// DifferenceWith creates a set of the elements of the set that are not among the provided elements.
+func (set BitSet[I]) DifferenceWith(elements ...I) *BitSet[I] {
+	result := &BitSet[I]{words: append([]uint64(nil), set.words...)}
+	result.Remove(elements...)
+	return result
+}
+
+// ThreeWay splits elements into three sets: common, only in the first set, and only in the second set.
+func (set BitSet[I]) ThreeWay(other *BitSet[I]) [3]*BitSet[I] {
+	return [3]*BitSet[I]{
+		set.Intersection(other),
+		set.Difference(other),
+		other.Difference(&set),
+	}
+}
+
+// SymmetricDifference creates a set of elements in exactly one of the sets.
+func (set BitSet[I]) SymmetricDifference(other *BitSet[I]) *BitSet[I] {
+	result := set.Union(other)
+	for i := range min(len(set.words), len(other.words)) {
+		result.words[i] = set.words[i] ^ other.words[i]
+	}
+	result.trim()
+	return result
+}
+
+// All iterates over the elements of the [BitSet], in ascending order.
+func (set BitSet[I]) All() iter.Seq[I] {
+	return func(yield func(I) bool) {
+		for i, w := range set.words {
+			for w != 0 {
+				if !yield(I(i*wordBits + bits.TrailingZeros64(w))) {
+					return
+				}
+				w &= w - 1
+			}
+		}
+	}
+}
+
+// Clear removes all elements from the [BitSet].
+func (set *BitSet[I]) Clear() { set.words = nil }
+
+// Clone returns a copy of the [BitSet].
+func (set BitSet[I]) Clone() api.Set[I] {
+	return &BitSet[I]{Compact: set.Compact, words: slices.Clone(set.words)}
+}
+
+// ContainsAny checks whether any of the elements is in the [BitSet].
+func (set BitSet[I]) ContainsAny(elements ...I) bool { return containsAny(set, elements) }
+
+// IsSubset checks whether all elements of the [BitSet] are in the other set.
+func (set BitSet[I]) IsSubset(other api.ReadOnlySet[I]) bool { return isSubset(set, other) }
+
+// IsSuperset checks whether all elements of the other set are in the [BitSet].
+func (set BitSet[I]) IsSuperset(other api.ReadOnlySet[I]) bool { return isSuperset(set, other) }
+
+// Equal checks whether the [BitSet] and the other set have the same elements.
+func (set BitSet[I]) Equal(other api.ReadOnlySet[I]) bool { return isEqual(set, other) }
+
+// Disjoint checks whether the [BitSet] and the other set have no elements in common.
+func (set BitSet[I]) Disjoint(other api.ReadOnlySet[I]) bool { return isDisjoint(set, other) }
+
+// MarshalJSON converts the [BitSet] to a JSON array, or a string of ranges if it is Compact.
+func (set BitSet[I]) MarshalJSON() ([]byte, error) {
+	if set.Compact {
+		return json.Marshal(set.rangesString())
+	}
+	return json.Marshal(set.Elements())
+}
+
+// UnmarshalJSON converts the JSON []byte, an array or a string of ranges, to a [BitSet].
+func (set *BitSet[I]) UnmarshalJSON(data []byte) error {
+	var ranges string
+	if err := json.Unmarshal(data, &ranges); err == nil {
+		return set.setRanges(ranges)
+	}
+	var slice []I
+	if err := json.Unmarshal(data, &slice); err != nil {
+		return err
+	}
+	return set.setElements(slice, false)
+}
+
+// MarshalYAML converts the [BitSet] to a YAML array, or a string of ranges if it is Compact.
+func (set BitSet[I]) MarshalYAML() (interface{}, error) {
+	if set.Compact {
+		return set.rangesString(), nil
+	}
+	return set.Elements(), nil
+}
+
+// UnmarshalYAML converts the YAML [yaml.Node], a list or a string of ranges, to a [BitSet].
+func (set *BitSet[I]) UnmarshalYAML(value *yaml.Node) error {
+	if value.Kind == yaml.ScalarNode {
+		return set.setRanges(value.Value)
+	}
+	var slice []I
+	if err := value.Decode(&slice); err != nil {
+		return err
+	}
+	return set.setElements(slice, false)
+}
+
+// locate returns the word index and bit mask of e.
+func (set BitSet[I]) locate(e I) (int, uint64) {
+	if e < 0 {
+		panic(errs.Newf("bitset: negative element %d", e))
+	}
+	return int(uint64(e) / wordBits), 1 << (uint64(e) % wordBits)
+}
+
+// grow makes sure the [BitSet] has the word at index.
+func (set *BitSet[I]) grow(word int) {
+	if word >= len(set.words) {
+		set.words = append(set.words, make([]uint64, word+1-len(set.words))...)
+	}
+}
+
+// trim drops the trailing empty words.
+func (set *BitSet[I]) trim() {
+	last := len(set.words)
+	for last > 0 && set.words[last-1] == 0 {
+		last--
+	}
+	set.words = set.words[:last]
+}
+
+// runs calls fn with the first and last element of every run of consecutive elements, in ascending order.
+func (set BitSet[I]) runs(fn func(min, max I)) {
+	largest := mathutil.MaxValue[I]()
+	start, ok := set.NextSet(0)
+	for ok {
+		end := start
+		for end < largest && set.Contains(end+1) {
+			end++
+		}
+		fn(start, end)
+		if end == largest {
+			return
+		}
+		start, ok = set.NextSet(end + 1)
+	}
+}
+
+// rangesString formats the [BitSet] as comma-separated runs, like "1-3,7".
+func (set BitSet[I]) rangesString() string {
+	var parts []string
+	set.runs(func(min, max I) {
+		if min == max {
+			parts = append(parts, fmt.Sprint(min))
+		} else {
+			parts = append(parts, fmt.Sprintf("%d-%d", min, max))
+		}
+	})
+	return strings.Join(parts, ",")
+}
+
+// setRanges replaces the elements of the [BitSet] with the comma-separated runs of text, like "1-3,7".
+func (set *BitSet[I]) setRanges(text string) error {
+	set.words = nil
+	set.Compact = true
+	if strings.TrimSpace(text) == "" {
+		return nil
+	}
+	for _, part := range strings.Split(text, ",") {
+		from, to, isRange := strings.Cut(strings.TrimSpace(part), "-")
+		min, err := mathutil.ParseNumber[I](from)
+		if err != nil {
+			return fmt.Errorf("parsing '%s' to bitset: %w", text, err)
+		}
+		max := min
+		if isRange {
+			if max, err = mathutil.ParseNumber[I](to); err != nil {
+				return fmt.Errorf("parsing '%s' to bitset: %w", text, err)
+			}
+		}
+		if min < 0 || max < min {
+			return fmt.Errorf("parsing '%s' to bitset: invalid range '%s'", text, part)
+		}
+		set.AddRange(min, max)
+	}
+	return nil
+}
+
+// setElements replaces the elements of the [BitSet], returning an error for negative elements.
+func (set *BitSet[I]) setElements(elements []I, compact bool) error {
+	set.words = nil
+	set.Compact = compact
+	for _, e := range elements {
+		if e < 0 {
+			return errs.Newf("bitset: negative element %d", e)
+		}
+	}
+	set.Add(elements...)
+	return nil
+}
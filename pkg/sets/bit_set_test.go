@@ -0,0 +1,207 @@
+package sets_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/toolvox/utilgo/pkg/mathutil"
+	"github.com/toolvox/utilgo/pkg/sets"
+	"github.com/toolvox/utilgo/test"
+	test_sets "github.com/toolvox/utilgo/test/sets_test"
+)
+
+func Test_BitSet(t *testing.T) {
+	t.Run("int", func(t *testing.T) {
+		test_sets.Run_Test_Set(t,
+			test.TestConstructorFor[*sets.BitSet[int]]{
+				NewFunc: func() *sets.BitSet[int] {
+					return sets.NewBitSet[int]()
+				},
+			},
+			test.TestDataFor[int]{
+				ElementFunc: func(i int) int { return i },
+			},
+			10_000,
+		)
+	})
+	t.Run("uint16", func(t *testing.T) {
+		test_sets.Run_Test_Set(t,
+			test.TestConstructorFor[*sets.BitSet[uint16]]{
+				NewFunc: func() *sets.BitSet[uint16] {
+					return sets.NewBitSet[uint16]()
+				},
+			},
+			test.TestDataFor[uint16]{
+				ElementFunc: func(i int) uint16 { return uint16(i * 3) },
+			},
+			10_000,
+		)
+	})
+
+	t.Run("Order", func(t *testing.T) {
+		must := require.New(t)
+		testSet := sets.NewBitSet(130, 5, 64, 63, 5)
+		must.Equal([]int{5, 63, 64, 130}, testSet.Elements())
+		must.Equal("{ 5, 63, 64, 130 }", testSet.String())
+		must.Equal(4, testSet.Len())
+		testSet.Remove(130, 1, -1)
+		must.Equal([]int{5, 63, 64}, testSet.Elements())
+		must.False(testSet.Contains(-1))
+		must.Panics(func() { testSet.Add(-1) })
+	})
+
+	t.Run("AddRange", func(t *testing.T) {
+		must := require.New(t)
+		testSet := sets.NewBitSet[int]()
+		testSet.AddRange(60, 130)
+		must.Equal(71, testSet.Len())
+		must.True(testSet.Contains(60, 63, 64, 127, 128, 130))
+		must.False(testSet.Contains(59))
+		must.False(testSet.Contains(131))
+		testSet.AddRange(3, 2)
+		must.Equal(71, testSet.Len())
+	})
+
+	t.Run("NextSet", func(t *testing.T) {
+		must := require.New(t)
+		testSet := sets.NewBitSet(3, 64, 200)
+		var got []int
+		for e, ok := testSet.NextSet(0); ok; e, ok = testSet.NextSet(e + 1) {
+			got = append(got, e)
+		}
+		must.Equal([]int{3, 64, 200}, got)
+
+		e, ok := testSet.NextSet(65)
+		must.True(ok)
+		must.Equal(200, e)
+		_, ok = testSet.NextSet(201)
+		must.False(ok)
+		_, ok = testSet.NextSet(10_000)
+		must.False(ok)
+
+		maxSet := sets.NewBitSet[uint8](0, 255)
+		var gotMax []uint8
+		for e, ok := maxSet.NextSet(0); ok; e, ok = maxSet.NextSet(e + 1) {
+			gotMax = append(gotMax, e)
+			if e == mathutil.MaxValue[uint8]() {
+				break
+			}
+		}
+		must.Equal([]uint8{0, 255}, gotMax)
+	})
+
+	t.Run("MaxValue", func(t *testing.T) {
+		must := require.New(t)
+		uint8Set := sets.NewBitSet[uint8](0, 1, 254, 255)
+		must.Equal("[0, 1] ∪ [254, 255]", uint8Set.Interval().String())
+		must.Equal("{255}", sets.NewBitSet[uint8](255).Interval().String())
+		uint8Set.Compact = true
+		bs, err := json.Marshal(uint8Set)
+		must.NoError(err)
+		must.Equal(`"0-1,254-255"`, string(bs))
+		bs, err = yaml.Marshal(uint8Set)
+		must.NoError(err)
+		must.Equal("0-1,254-255\n", string(bs))
+
+		int8Set := sets.NewBitSet[int8](0, 126, 127)
+		must.Equal("{0} ∪ [126, 127]", int8Set.Interval().String())
+		must.Equal("{127}", sets.NewBitSet[int8](127).Interval().String())
+		int8Set.Compact = true
+		bs, err = json.Marshal(int8Set)
+		must.NoError(err)
+		must.Equal(`"0,126-127"`, string(bs))
+
+		full := sets.NewBitSet[uint8]()
+		full.AddRange(0, 255)
+		must.Equal("[0, 255]", full.Interval().String())
+	})
+
+	t.Run("Operations", func(t *testing.T) {
+		must := require.New(t)
+		a, b := sets.NewBitSet(4, 1, 3, 200), sets.NewBitSet(3, 2, 4, 8)
+		must.Equal([]int{1, 2, 3, 4, 8, 200}, a.Union(b).Elements())
+		must.Equal([]int{0, 1, 3, 4, 200}, a.UnionWith(0, 1).Elements())
+		must.Equal([]int{3, 4}, a.Intersection(b).Elements())
+		must.Equal([]int{1, 3}, a.IntersectionWith(3, 1, 7, -2).Elements())
+		must.Equal([]int{1, 200}, a.Difference(b).Elements())
+		must.Equal([]int{3, 4}, a.DifferenceWith(1, 200).Elements())
+
+		threeWay := a.ThreeWay(b)
+		must.Equal([]int{3, 4}, threeWay[0].Elements())
+		must.Equal([]int{1, 200}, threeWay[1].Elements())
+		must.Equal([]int{2, 8}, threeWay[2].Elements())
+		must.Equal([]int{1, 2, 8, 200}, a.SymmetricDifference(b).Elements())
+		must.Equal([]int{1, 2, 8, 200}, b.SymmetricDifference(a).Elements())
+	})
+
+	t.Run("Interval", func(t *testing.T) {
+		must := require.New(t)
+		testSet := sets.NewBitSet(1, 2, 3, 7, 63, 64, 65)
+		interval := testSet.Interval()
+		must.Equal(1, interval.Min())
+		must.Equal(65, interval.Max())
+		must.Len(interval.Intervals(), 3)
+		must.True(interval.Contains(2))
+		must.False(interval.Contains(4))
+		must.True(sets.NewBitSet[int]().Interval().IsEmpty())
+
+		fromInterval, err := sets.NewBitSetFromInterval(interval)
+		must.NoError(err)
+		must.Equal(testSet.Elements(), fromInterval.Elements())
+
+		fromInterval, err = sets.NewBitSetFromInterval(mathutil.NewClosed(62, 66))
+		must.NoError(err)
+		must.Equal([]int{62, 63, 64, 65, 66}, fromInterval.Elements())
+
+		fromInterval, err = sets.NewBitSetFromInterval(mathutil.NewInterval(2, false, 5, false))
+		must.NoError(err)
+		must.Equal([]int{3, 4}, fromInterval.Elements())
+
+		_, err = sets.NewBitSetFromInterval(mathutil.NewClosed(-1, 3))
+		must.Error(err)
+		_, err = sets.NewBitSetFromInterval(mathutil.NewRightUnbounded(3, true))
+		must.Error(err)
+	})
+}
+
+func Test_BitSet_Encoding(t *testing.T) {
+	must := require.New(t)
+	testSet := sets.NewBitSet[uint](7, 1, 2, 3, 9, 10)
+
+	bs, err := json.Marshal(testSet)
+	must.NoError(err)
+	must.Equal(`[1,2,3,7,9,10]`, string(bs))
+	bs, err = yaml.Marshal(testSet)
+	must.NoError(err)
+	must.Equal("- 1\n- 2\n- 3\n- 7\n- 9\n- 10\n", string(bs))
+
+	testSet.Compact = true
+	bs, err = json.Marshal(testSet)
+	must.NoError(err)
+	must.Equal(`"1-3,7,9-10"`, string(bs))
+	bs, err = yaml.Marshal(testSet)
+	must.NoError(err)
+	must.Equal("1-3,7,9-10\n", string(bs))
+
+	var jsonSet sets.BitSet[uint]
+	must.NoError(json.Unmarshal([]byte(`"1-3, 7"`), &jsonSet))
+	must.Equal([]uint{1, 2, 3, 7}, jsonSet.Elements())
+	must.True(jsonSet.Compact)
+	must.NoError(json.Unmarshal([]byte(`[5,4,5]`), &jsonSet))
+	must.Equal([]uint{4, 5}, jsonSet.Elements())
+	must.False(jsonSet.Compact)
+	must.Error(json.Unmarshal([]byte(`"3-1"`), &jsonSet))
+	must.Error(json.Unmarshal([]byte(`"a"`), &jsonSet))
+
+	var yamlSet *sets.BitSet[uint]
+	must.NoError(yaml.Unmarshal([]byte("1-3,7,9-10\n"), &yamlSet))
+	must.Equal(testSet.Elements(), yamlSet.Elements())
+	must.NoError(yaml.Unmarshal([]byte("- 2\n- 1\n"), &yamlSet))
+	must.Equal([]uint{1, 2}, yamlSet.Elements())
+
+	var intSet sets.BitSet[int]
+	must.Error(json.Unmarshal([]byte(`[1,-1]`), &intSet))
+}
@@ -2,9 +2,8 @@ package sets
 
 import (
 	"encoding/json"
-	"fmt"
+	"iter"
 	"maps"
-	"strings"
 
 	"gopkg.in/yaml.v3"
 
@@ -29,24 +28,9 @@ func NewSet[C comparable](elements ...C) Set[C] {
 	return result
 }
 
-// String returns the string representation of the [Set].
-func (set Set[C]) String() string {
-	var sb strings.Builder
-	sb.WriteRune('{')
-	var notFirst bool
-	for e := range set {
-		if notFirst {
-			sb.WriteRune(',')
-		} else {
-			notFirst = true
-		}
-		sb.WriteRune(' ')
-		fmt.Fprint(&sb, e)
-	}
-	sb.WriteRune(' ')
-	sb.WriteRune('}')
-	return sb.String()
-}
+// String returns the string representation of the [Set], in sorted order.
+// See [RegisterComparator] for how elements are ordered.
+func (set Set[C]) String() string { return formatElements(sortElements(set.Elements())) }
 
 // Len counts the elements in the [Set].
 func (set Set[C]) Len() int { return len(set) }
@@ -165,14 +149,53 @@ func (set Set[C]) ThreeWay(other Set[C]) [3]Set[C] {
 	return blr
 }
 
-// MarshalJSON converts the [Set] to a JSON array.
-func (set Set[T]) MarshalJSON() ([]byte, error) {
-	slice := make([]T, 0, len(set))
-	for key := range set {
-		slice = append(slice, key)
+// SymmetricDifference creates a set of elements in exactly one of the sets.
+func (set Set[C]) SymmetricDifference(other Set[C]) Set[C] {
+	result := make(Set[C])
+	for k := range set {
+		if _, ok := other[k]; !ok {
+			result[k] = U
+		}
+	}
+	for k := range other {
+		if _, ok := set[k]; !ok {
+			result[k] = U
+		}
 	}
+	return result
+}
+
+// All iterates over the elements of the [Set].
+func (set Set[C]) All() iter.Seq[C] { return maps.Keys(set) }
 
-	return json.Marshal(slice)
+// Clear removes all elements from the [Set].
+func (set Set[C]) Clear() { clear(set) }
+
+// Clone returns a copy of the [Set].
+func (set Set[C]) Clone() api.Set[C] {
+	result := make(Set[C], len(set))
+	maps.Copy(result, set)
+	return result
+}
+
+// ContainsAny checks whether any of the elements is in the [Set].
+func (set Set[C]) ContainsAny(elements ...C) bool { return containsAny(set, elements) }
+
+// IsSubset checks whether all elements of the [Set] are in the other set.
+func (set Set[C]) IsSubset(other api.ReadOnlySet[C]) bool { return isSubset(set, other) }
+
+// IsSuperset checks whether all elements of the other set are in the [Set].
+func (set Set[C]) IsSuperset(other api.ReadOnlySet[C]) bool { return isSuperset(set, other) }
+
+// Equal checks whether the [Set] and the other set have the same elements.
+func (set Set[C]) Equal(other api.ReadOnlySet[C]) bool { return isEqual(set, other) }
+
+// Disjoint checks whether the [Set] and the other set have no elements in common.
+func (set Set[C]) Disjoint(other api.ReadOnlySet[C]) bool { return isDisjoint(set, other) }
+
+// MarshalJSON converts the [Set] to a JSON array, in sorted order.
+func (set Set[T]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(sortElements(set.Elements()))
 }
 
 // UnmarshalJSON converts the JSON []byte to a [Set].
@@ -186,27 +209,18 @@ func (set *Set[T]) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
-// MarshalYAML converts the [Set] to a YAML array.
+// MarshalYAML converts the [Set] to a YAML array, in sorted order.
 func (set Set[T]) MarshalYAML() (interface{}, error) {
-	slice := make([]T, 0, len(set))
-	for key := range set {
-		slice = append(slice, key)
-	}
-	return slice, nil
+	return sortElements(set.Elements()), nil
 }
 
 // UnmarshalYAML converts the YAML [yaml.Node] (representing a YAML list) to a [Set].
 func (set *Set[T]) UnmarshalYAML(value *yaml.Node) error {
 	var slice []T
-	err := value.Decode(&slice)
-	if err != nil {
+	if err := value.Decode(&slice); err != nil {
 		return err
 	}
-	if *set == nil {
-		n := NewSet[T](slice...)
-		*set = n
-	} else {
-		set.Add(slice...)
-	}
+
+	*set = NewSet(slice...)
 	return nil
 }
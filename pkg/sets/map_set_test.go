@@ -1,6 +1,7 @@
 package sets_test
 
 import (
+	"cmp"
 	"encoding/json"
 	"fmt"
 	"testing"
@@ -159,6 +160,15 @@ func TestThreeWay(t *testing.T) {
 	}
 }
 
+func TestSymmetricDifference(t *testing.T) {
+	s1 := sets.NewSet(1, 2)
+	s2 := sets.NewSet(2, 3)
+	symmetric := s1.SymmetricDifference(s2)
+	if len(symmetric) != 2 || !symmetric.Contains(1, 3) {
+		t.Errorf("SymmetricDifference does not contain the correct elements")
+	}
+}
+
 func Test_Set_Encoding(t *testing.T) {
 	testSet := sets.NewSet("hello", "goodbye", "salute")
 	t.Run("YAML", func(t *testing.T) {
@@ -198,3 +208,55 @@ func Test_Set_Encoding(t *testing.T) {
 	})
 
 }
+
+func Test_Set_Deterministic(t *testing.T) {
+	t.Run("Ordered", func(t *testing.T) {
+		must := require.New(t)
+		testSet := sets.NewSet(10, 3, -1, 2, 100)
+		for range 10 {
+			must.Equal("{ -1, 2, 3, 10, 100 }", testSet.String())
+			bs, err := json.Marshal(testSet)
+			must.NoError(err)
+			must.Equal(`[-1,2,3,10,100]`, string(bs))
+			bs, err = yaml.Marshal(testSet)
+			must.NoError(err)
+			must.Equal("- -1\n- 2\n- 3\n- 10\n- 100\n", string(bs))
+		}
+
+		type ext string
+		extSet := sets.NewSet[ext](".md", ".go", ".json")
+		bs, err := json.Marshal(extSet)
+		must.NoError(err)
+		must.Equal(`[".go",".json",".md"]`, string(bs))
+	})
+
+	t.Run("Comparator", func(t *testing.T) {
+		must := require.New(t)
+		type point struct{ X, Y int }
+		testSet := sets.NewSet(point{2, 1}, point{1, 2}, point{1, 1})
+		must.Equal("{ {1 1}, {1 2}, {2 1} }", testSet.String())
+
+		sets.RegisterComparator(func(a, b point) int {
+			return cmp.Or(cmp.Compare(a.Y, b.Y), cmp.Compare(a.X, b.X))
+		})
+		defer sets.RegisterComparator[point](nil)
+		must.Equal("{ {1 1}, {2 1}, {1 2} }", testSet.String())
+		bs, err := json.Marshal(testSet)
+		must.NoError(err)
+		must.Equal(`[{"X":1,"Y":1},{"X":2,"Y":1},{"X":1,"Y":2}]`, string(bs))
+
+		sets.RegisterComparator(func(a, b point) int { return cmp.Compare(a.Y, b.Y) })
+		must.Equal("{ {1 1}, {2 1}, {1 2} }", testSet.String(), "ties are ordered like by default")
+
+		sets.RegisterComparator[point](nil)
+		must.Equal("{ {1 1}, {1 2}, {2 1} }", testSet.String())
+
+		type label string
+		for range 20 {
+			must.Equal(`{ 1, 1, 1, 1, 1 }`, sets.NewSet[any]("1", label("1"), 1, int64(1), 1.0).String())
+			bs, err = json.Marshal(sets.NewSet[any]("1", label("1"), 1, int64(1), 1.0))
+			must.NoError(err)
+			must.Equal(`[1,1,1,"1","1"]`, string(bs))
+		}
+	})
+}
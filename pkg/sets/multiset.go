@@ -0,0 +1,231 @@
+package sets
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"iter"
+	"maps"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/toolvox/utilgo/api"
+	"github.com/toolvox/utilgo/pkg/errs"
+)
+
+// Multiset uses a go map to represent a bag of elements of type C, counting the occurrences of each element.
+// Elements are only kept while their count is positive.
+type Multiset[C comparable] map[C]int
+
+// ElementCount is an element of a [Multiset] with its count.
+type ElementCount[C comparable] struct {
+	Element C
+	Count   int
+}
+
+// NewMultiset initializes a new [Multiset], counting every occurrence of the given elements.
+func NewMultiset[C comparable](elements ...C) Multiset[C] {
+	result := make(Multiset[C], len(elements))
+	result.Add(1, elements...)
+	return result
+}
+
+// NewMultisetFromSet initializes a new [Multiset] with a single occurrence of every element of the set.
+func NewMultisetFromSet[C comparable](set api.ReadOnlySet[C]) Multiset[C] {
+	return NewMultiset(set.Elements()...)
+}
+
+// String returns the string representation of the [Multiset], like "{ a: 2, b: 1 }", from the most common element.
+func (ms Multiset[C]) String() string {
+	var sb strings.Builder
+	sb.WriteRune('{')
+	for i, ec := range ms.MostCommon(-1) {
+		if i != 0 {
+			sb.WriteRune(',')
+		}
+		fmt.Fprintf(&sb, " %v: %d", ec.Element, ec.Count)
+	}
+	sb.WriteRune(' ')
+	sb.WriteRune('}')
+	return sb.String()
+}
+
+// Len counts the occurrences of all elements in the [Multiset].
+func (ms Multiset[C]) Len() int {
+	var total int
+	for _, count := range ms {
+		total += count
+	}
+	return total
+}
+
+// Distinct counts the unique elements in the [Multiset].
+func (ms Multiset[C]) Distinct() int { return len(ms) }
+
+// Elements returns the unique elements of the [Multiset].
+func (ms Multiset[C]) Elements() []C { return slices.Collect(maps.Keys(ms)) }
+
+// All iterates over the unique elements of the [Multiset] and their counts.
+func (ms Multiset[C]) All() iter.Seq2[C, int] { return maps.All(ms) }
+
+// Count returns the number of occurrences of the element in the [Multiset].
+func (ms Multiset[C]) Count(element C) int { return ms[element] }
+
+// Add n occurrences of each of the elements to the [Multiset].
+// Non-positive n adds nothing.
+func (ms Multiset[C]) Add(n int, elements ...C) {
+	if n <= 0 {
+		return
+	}
+	for _, e := range elements {
+		ms[e] += n
+	}
+}
+
+// Remove up to n occurrences of each of the elements from the [Multiset].
+// Non-positive n removes nothing.
+func (ms Multiset[C]) Remove(n int, elements ...C) {
+	if n <= 0 {
+		return
+	}
+	for _, e := range elements {
+		if ms[e] <= n {
+			delete(ms, e)
+		} else {
+			ms[e] -= n
+		}
+	}
+}
+
+// Contains checked whether all elements are in the [Multiset].
+func (ms Multiset[C]) Contains(elements ...C) bool {
+	for _, e := range elements {
+		if _, ok := ms[e]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// MostCommon returns the k most common elements of the [Multiset] with their counts, from the most common.
+// Ties are ordered like the elements of printed sets, see [RegisterComparator].
+// Negative k returns all elements.
+func (ms Multiset[C]) MostCommon(k int) []ElementCount[C] {
+	result := make([]ElementCount[C], 0, len(ms))
+	for e, count := range ms {
+		result = append(result, ElementCount[C]{Element: e, Count: count})
+	}
+	compare := comparatorFor[C]()
+	slices.SortFunc(result, func(a, b ElementCount[C]) int {
+		if c := cmp.Compare(b.Count, a.Count); c != 0 {
+			return c
+		}
+		return compare(a.Element, b.Element)
+	})
+	if k >= 0 && k < len(result) {
+		result = result[:k]
+	}
+	return result
+}
+
+// Set returns the unique elements of the [Multiset] as a [Set].
+func (ms Multiset[C]) Set() Set[C] {
+	result := make(Set[C], len(ms))
+	for e := range ms {
+		result[e] = U
+	}
+	return result
+}
+
+// Equal checks whether both multisets have the same elements with the same counts.
+func (ms Multiset[C]) Equal(other Multiset[C]) bool { return maps.Equal(ms, other) }
+
+// Union creates a multiset of the elements of both, with the larger count of each.
+func (ms Multiset[C]) Union(other Multiset[C]) Multiset[C] {
+	result := maps.Clone(ms)
+	if result == nil {
+		result = make(Multiset[C], len(other))
+	}
+	for e, count := range other {
+		result[e] = max(result[e], count)
+	}
+	return result
+}
+
+// Intersection creates a multiset of the elements common to both, with the smaller count of each.
+func (ms Multiset[C]) Intersection(other Multiset[C]) Multiset[C] {
+	result := make(Multiset[C], min(len(ms), len(other)))
+	for e, count := range ms {
+		if otherCount, ok := other[e]; ok {
+			result[e] = min(count, otherCount)
+		}
+	}
+	return result
+}
+
+// Sum creates a multiset of the elements of both, with the counts added up.
+func (ms Multiset[C]) Sum(other Multiset[C]) Multiset[C] {
+	result := maps.Clone(ms)
+	if result == nil {
+		result = make(Multiset[C], len(other))
+	}
+	for e, count := range other {
+		result[e] += count
+	}
+	return result
+}
+
+// Difference creates a multiset of the elements of the multiset, with the counts of the other subtracted.
+// Elements whose count drops to zero or below are left out.
+func (ms Multiset[C]) Difference(other Multiset[C]) Multiset[C] {
+	result := make(Multiset[C], len(ms))
+	for e, count := range ms {
+		if count > other[e] {
+			result[e] = count - other[e]
+		}
+	}
+	return result
+}
+
+// MarshalJSON converts the [Multiset] to a JSON object of counts.
+func (ms Multiset[C]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[C]int(ms))
+}
+
+// UnmarshalJSON converts the JSON []byte (representing an object of counts) to a [Multiset].
+func (ms *Multiset[C]) UnmarshalJSON(data []byte) error {
+	var counts map[C]int
+	if err := json.Unmarshal(data, &counts); err != nil {
+		return err
+	}
+	return ms.setCounts(counts)
+}
+
+// MarshalYAML converts the [Multiset] to a YAML map of counts.
+func (ms Multiset[C]) MarshalYAML() (interface{}, error) {
+	return map[C]int(ms), nil
+}
+
+// UnmarshalYAML converts the YAML [yaml.Node] (representing a YAML map of counts) to a [Multiset].
+func (ms *Multiset[C]) UnmarshalYAML(value *yaml.Node) error {
+	var counts map[C]int
+	if err := value.Decode(&counts); err != nil {
+		return err
+	}
+	return ms.setCounts(counts)
+}
+
+// setCounts replaces the [Multiset] with the counts, dropping zero counts and rejecting negative ones.
+func (ms *Multiset[C]) setCounts(counts map[C]int) error {
+	result := make(Multiset[C], len(counts))
+	for e, count := range counts {
+		if count < 0 {
+			return errs.Newf("multiset: negative count %d for %v", count, e)
+		}
+		result.Add(count, e)
+	}
+	*ms = result
+	return nil
+}
@@ -0,0 +1,126 @@
+package sets_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/toolvox/utilgo/pkg/sets"
+)
+
+func Test_Multiset(t *testing.T) {
+	t.Run("Counts", func(t *testing.T) {
+		must := require.New(t)
+		bag := sets.NewMultiset("go", "md", "go", "json", "go", "md")
+		must.Equal(3, bag.Count("go"))
+		must.Equal(2, bag.Count("md"))
+		must.Equal(0, bag.Count("txt"))
+		must.Equal(6, bag.Len())
+		must.Equal(3, bag.Distinct())
+		must.True(bag.Contains("go", "json"))
+		must.False(bag.Contains("go", "txt"))
+		must.ElementsMatch([]string{"go", "md", "json"}, bag.Elements())
+		must.Equal("{ go: 3, md: 2, json: 1 }", bag.String())
+
+		bag.Add(2, "txt", "md")
+		bag.Add(0, "yaml")
+		bag.Add(-1, "go")
+		must.Equal(4, bag.Count("md"))
+		must.Equal(2, bag.Count("txt"))
+		must.False(bag.Contains("yaml"))
+		must.Equal(3, bag.Count("go"))
+
+		bag.Remove(1, "go", "json", "yaml")
+		must.Equal(2, bag.Count("go"))
+		must.False(bag.Contains("json"))
+		bag.Remove(10, "md")
+		bag.Remove(0, "go")
+		must.False(bag.Contains("md"))
+		must.Equal(2, bag.Count("go"))
+		must.Equal(2, bag.Distinct())
+
+		var all []sets.ElementCount[string]
+		for e, count := range bag.All() {
+			all = append(all, sets.ElementCount[string]{Element: e, Count: count})
+		}
+		must.ElementsMatch([]sets.ElementCount[string]{{"go", 2}, {"txt", 2}}, all)
+	})
+
+	t.Run("MostCommon", func(t *testing.T) {
+		must := require.New(t)
+		bag := sets.NewMultiset('c', 'a', 'b', 'a', 'b', 'a', 'd')
+		must.Equal([]sets.ElementCount[rune]{{'a', 3}, {'b', 2}}, bag.MostCommon(2))
+		must.Equal([]sets.ElementCount[rune]{{'a', 3}, {'b', 2}, {'c', 1}, {'d', 1}}, bag.MostCommon(-1))
+		must.Len(bag.MostCommon(10), 4)
+		must.Empty(bag.MostCommon(0))
+		must.Empty(sets.NewMultiset[int]().MostCommon(3))
+
+		numbers := sets.NewMultiset(100, 9, 100, 9, -1)
+		must.Equal([]sets.ElementCount[int]{{9, 2}, {100, 2}, {-1, 1}}, numbers.MostCommon(-1))
+		must.Equal("{ 9: 2, 100: 2, -1: 1 }", numbers.String())
+
+		mixed := sets.NewMultiset[any](2, "a", 1.5, "2")
+		must.Equal([]sets.ElementCount[any]{{1.5, 1}, {2, 1}, {"2", 1}, {"a", 1}}, mixed.MostCommon(-1))
+	})
+
+	t.Run("Set", func(t *testing.T) {
+		must := require.New(t)
+		bag := sets.NewMultisetFromSet[int](sets.NewTinySet(1, 2, 3))
+		must.Equal(1, bag.Count(2))
+		must.Equal(3, bag.Len())
+		bag.Add(5, 2)
+		must.True(sets.NewSet(1, 2, 3).Equal(bag.Set()))
+	})
+
+	t.Run("Operations", func(t *testing.T) {
+		must := require.New(t)
+		a := sets.Multiset[string]{"x": 3, "y": 1}
+		b := sets.Multiset[string]{"x": 1, "y": 2, "z": 4}
+		must.Equal(sets.Multiset[string]{"x": 3, "y": 2, "z": 4}, a.Union(b))
+		must.Equal(sets.Multiset[string]{"x": 1, "y": 1}, a.Intersection(b))
+		must.Equal(sets.Multiset[string]{"x": 4, "y": 3, "z": 4}, a.Sum(b))
+		must.Equal(sets.Multiset[string]{"x": 2}, a.Difference(b))
+		must.Equal(sets.Multiset[string]{"y": 1, "z": 4}, b.Difference(a))
+		must.Equal(sets.Multiset[string]{"x": 3, "y": 1}, a, "operations must not change the multiset")
+
+		must.True(a.Equal(sets.NewMultiset("x", "y", "x", "x")))
+		must.False(a.Equal(b))
+
+		var empty sets.Multiset[string]
+		must.Equal(b, empty.Union(b))
+		must.Equal(b, empty.Sum(b))
+		must.Empty(empty.Intersection(b))
+	})
+}
+
+func Test_Multiset_Encoding(t *testing.T) {
+	must := require.New(t)
+	bag := sets.NewMultiset("b", "a", "b")
+
+	bs, err := json.Marshal(bag)
+	must.NoError(err)
+	must.Equal(`{"a":1,"b":2}`, string(bs))
+	var jsonBag sets.Multiset[string]
+	must.NoError(json.Unmarshal([]byte(`{"a":1,"b":2,"c":0}`), &jsonBag))
+	must.Equal(bag, jsonBag)
+	must.Error(json.Unmarshal([]byte(`{"a":-1}`), &jsonBag))
+	must.Error(json.Unmarshal([]byte(`["a"]`), &jsonBag))
+
+	bs, err = yaml.Marshal(bag)
+	must.NoError(err)
+	must.Equal("a: 1\nb: 2\n", string(bs))
+	var yamlBag sets.Multiset[string]
+	must.NoError(yaml.Unmarshal(bs, &yamlBag))
+	must.Equal(bag, yamlBag)
+	must.Error(yaml.Unmarshal([]byte("a: -2\n"), &yamlBag))
+
+	intBag := sets.NewMultiset(3, 1, 3)
+	bs, err = json.Marshal(intBag)
+	must.NoError(err)
+	must.Equal(`{"1":1,"3":2}`, string(bs))
+	var jsonIntBag sets.Multiset[int]
+	must.NoError(json.Unmarshal(bs, &jsonIntBag))
+	must.Equal(intBag, jsonIntBag)
+}
@@ -0,0 +1,114 @@
+package sets
+
+import (
+	"cmp"
+	"fmt"
+	"reflect"
+	"slices"
+	"sync"
+)
+
+// comparators holds the registered element comparators, by element type.
+var comparators = struct {
+	sync.RWMutex
+	byType map[reflect.Type]any
+}{byType: map[reflect.Type]any{}}
+
+// RegisterComparator sets how elements of type C are ordered when a [Set] of C is printed or marshaled.
+// Elements the comparator ties are ordered like by default. Registering a nil compare restores the default order.
+//
+// By default, numbers and strings are ordered by value, and other elements by their string representation,
+// then by their type and Go syntax representation, so 1 comes before int64(1) and "1".
+// Elements which are still tied, like distinct pointers to equal values, are in no particular order.
+func RegisterComparator[C comparable](compare func(a, b C) int) {
+	comparators.Lock()
+	defer comparators.Unlock()
+	if compare == nil {
+		delete(comparators.byType, reflect.TypeFor[C]())
+		return
+	}
+	comparators.byType[reflect.TypeFor[C]()] = compare
+}
+
+// comparatorFor returns the registered comparator for elements of type C, falling back to the default one on ties.
+// It is resolved once per sort, rather than on every comparison.
+func comparatorFor[C comparable]() func(a, b C) int {
+	fallback := defaultComparator[C]()
+	comparators.RLock()
+	registered, ok := comparators.byType[reflect.TypeFor[C]()]
+	comparators.RUnlock()
+	if !ok {
+		return fallback
+	}
+	compare := registered.(func(a, b C) int)
+	return func(a, b C) int {
+		if c := compare(a, b); c != 0 {
+			return c
+		}
+		return fallback(a, b)
+	}
+}
+
+// sortElements sorts the elements in place with the comparator for C, and returns them.
+func sortElements[C comparable](elements []C) []C {
+	slices.SortFunc(elements, comparatorFor[C]())
+	return elements
+}
+
+// defaultComparator returns the default order of elements of type C, chosen by its kind.
+// Interfaces hold elements of any kind, so they are compared by the kinds of their values.
+func defaultComparator[C comparable]() func(a, b C) int {
+	switch kind := reflect.TypeFor[C]().Kind(); {
+	case kind == reflect.Interface:
+		return compareDynamic[C]
+	case isOrdered(kind):
+		return func(a, b C) int { return compareValues(reflect.ValueOf(a), reflect.ValueOf(b)) }
+	}
+	return compareFormatted[C]
+}
+
+// compareDynamic orders elements by value if both are numbers or strings of the same kind, and by their formats otherwise.
+func compareDynamic[C comparable](a, b C) int {
+	va, vb := reflect.ValueOf(a), reflect.ValueOf(b)
+	if va.Kind() == vb.Kind() && isOrdered(va.Kind()) {
+		if c := compareValues(va, vb); c != 0 {
+			return c
+		}
+	}
+	return compareFormatted(a, b)
+}
+
+// compareFormatted orders elements by their string representation, and ties by their type and Go syntax representation.
+func compareFormatted[C comparable](a, b C) int {
+	if c := cmp.Compare(fmt.Sprint(a), fmt.Sprint(b)); c != 0 {
+		return c
+	}
+	if c := cmp.Compare(fmt.Sprintf("%T", a), fmt.Sprintf("%T", b)); c != 0 {
+		return c
+	}
+	return cmp.Compare(fmt.Sprintf("%#v", a), fmt.Sprintf("%#v", b))
+}
+
+// isOrdered checks whether values of the kind are numbers or strings, ordered by [compareValues].
+func isOrdered(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64, reflect.String:
+		return true
+	}
+	return false
+}
+
+// compareValues orders numbers or strings of the same kind by value.
+func compareValues(va, vb reflect.Value) int {
+	switch va.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(va.Int(), vb.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(va.Uint(), vb.Uint())
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(va.Float(), vb.Float())
+	}
+	return cmp.Compare(va.String(), vb.String())
+}
@@ -0,0 +1,227 @@
+package sets
+
+import (
+	"encoding/json"
+	"fmt"
+	"iter"
+	"slices"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/toolvox/utilgo/api"
+)
+
+// OrderedSet represents a set of elements of type C that remembers the order they were first added in.
+// Its [OrderedSet.Elements] and [OrderedSet.String] are stable, which makes it fit for golden tests.
+type OrderedSet[C comparable] struct {
+	elements []C
+	index    map[C]int
+}
+
+// NewOrderedSet initializes a new [OrderedSet] with the given elements, ensuring uniqueness.
+func NewOrderedSet[C comparable](elements ...C) *OrderedSet[C] {
+	result := &OrderedSet[C]{index: make(map[C]int, len(elements))}
+	result.Add(elements...)
+	return result
+}
+
+// String returns the string representation of the [OrderedSet], in insertion order.
+func (set OrderedSet[C]) String() string { return formatElements(set.elements) }
+
+// Len counts the elements in the [OrderedSet].
+func (set OrderedSet[C]) Len() int { return len(set.elements) }
+
+// Elements returns the unique elements of the [OrderedSet], in insertion order.
+func (set OrderedSet[C]) Elements() []C {
+	return append(make([]C, 0, len(set.elements)), set.elements...)
+}
+
+// Add unique elements to the end of the [OrderedSet].
+// Repeated elements will be discarded, keeping their original position.
+func (set *OrderedSet[C]) Add(elements ...C) {
+	if set.index == nil {
+		set.index = make(map[C]int, len(elements))
+	}
+	for _, e := range elements {
+		if _, ok := set.index[e]; ok {
+			continue
+		}
+		set.index[e] = len(set.elements)
+		set.elements = append(set.elements, e)
+	}
+}
+
+// Contains checked whether all elements are in the [OrderedSet].
+func (set OrderedSet[C]) Contains(elements ...C) bool {
+	for _, e := range elements {
+		if _, ok := set.index[e]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Remove any existing elements from the [OrderedSet], keeping the order of the rest.
+func (set *OrderedSet[C]) Remove(elements ...C) {
+	var removed bool
+	for _, e := range elements {
+		if _, ok := set.index[e]; ok {
+			delete(set.index, e)
+			removed = true
+		}
+	}
+	if !removed {
+		return
+	}
+
+	kept := set.elements[:0]
+	for _, e := range set.elements {
+		if _, ok := set.index[e]; ok {
+			set.index[e] = len(kept)
+			kept = append(kept, e)
+		}
+	}
+	clear(set.elements[len(kept):])
+	set.elements = kept
+}
+
+// IndexOf returns the position of the element in the [OrderedSet], or -1 if it is not in the set.
+func (set OrderedSet[C]) IndexOf(element C) int {
+	if i, ok := set.index[element]; ok {
+		return i
+	}
+	return -1
+}
+
+// Union combines two sets into a new one containing elements from both, those of the set first.
+func (set OrderedSet[C]) Union(other *OrderedSet[C]) *OrderedSet[C] {
+	return set.UnionWith(other.elements...)
+}
+
+// UnionWith adds multiple elements to a copy of the set and returns the resulting set.
+func (set OrderedSet[C]) UnionWith(elements ...C) *OrderedSet[C] {
+	result := NewOrderedSet(set.elements...)
+	result.Add(elements...)
+	return result
+}
+
+// Intersection creates a set of elements common to both sets, in the order of the set.
+func (set OrderedSet[C]) Intersection(other *OrderedSet[C]) *OrderedSet[C] {
+	result := NewOrderedSet[C]()
+	for _, e := range set.elements {
+		if other.Contains(e) {
+			result.Add(e)
+		}
+	}
+	return result
+}
+
+// IntersectionWith forms a set from common elements of the set and the provided elements, in the order of the set.
+func (set OrderedSet[C]) IntersectionWith(elements ...C) *OrderedSet[C] {
+	return set.Intersection(NewOrderedSet(elements...))
+}
+
+// Difference creates a set of elements in the first set but not in the second, in the order of the set.
+func (set OrderedSet[C]) Difference(other *OrderedSet[C]) *OrderedSet[C] {
+	result := NewOrderedSet[C]()
+	for _, e := range set.elements {
+		if !other.Contains(e) {
+			result.Add(e)
+		}
+	}
+	return result
+}
+
+// DifferenceWith creates a set of the elements of the set that are not among the provided elements.
+func (set OrderedSet[C]) DifferenceWith(elements ...C) *OrderedSet[C] {
+	return set.Difference(NewOrderedSet(elements...))
+}
+
+// ThreeWay splits elements into three sets: common, only in the first set, and only in the second set.
+func (set OrderedSet[C]) ThreeWay(other *OrderedSet[C]) [3]*OrderedSet[C] {
+	return [3]*OrderedSet[C]{
+		set.Intersection(other),
+		set.Difference(other),
+		other.Difference(&set),
+	}
+}
+
+// SymmetricDifference creates a set of elements in exactly one of the sets, those of the set first.
+func (set OrderedSet[C]) SymmetricDifference(other *OrderedSet[C]) *OrderedSet[C] {
+	return set.Difference(other).Union(other.Difference(&set))
+}
+
+// All iterates over the elements of the [OrderedSet], in insertion order.
+func (set OrderedSet[C]) All() iter.Seq[C] { return slices.Values(set.elements) }
+
+// Clear removes all elements from the [OrderedSet].
+func (set *OrderedSet[C]) Clear() {
+	clear(set.elements)
+	set.elements = set.elements[:0]
+	clear(set.index)
+}
+
+// Clone returns a copy of the [OrderedSet].
+func (set OrderedSet[C]) Clone() api.Set[C] { return NewOrderedSet(set.elements...) }
+
+// ContainsAny checks whether any of the elements is in the [OrderedSet].
+func (set OrderedSet[C]) ContainsAny(elements ...C) bool { return containsAny(set, elements) }
+
+// IsSubset checks whether all elements of the [OrderedSet] are in the other set.
+func (set OrderedSet[C]) IsSubset(other api.ReadOnlySet[C]) bool { return isSubset(set, other) }
+
+// IsSuperset checks whether all elements of the other set are in the [OrderedSet].
+func (set OrderedSet[C]) IsSuperset(other api.ReadOnlySet[C]) bool { return isSuperset(set, other) }
+
+// Equal checks whether the [OrderedSet] and the other set have the same elements.
+func (set OrderedSet[C]) Equal(other api.ReadOnlySet[C]) bool { return isEqual(set, other) }
+
+// Disjoint checks whether the [OrderedSet] and the other set have no elements in common.
+func (set OrderedSet[C]) Disjoint(other api.ReadOnlySet[C]) bool { return isDisjoint(set, other) }
+
+// MarshalJSON converts the [OrderedSet] to a JSON array, in insertion order.
+func (set OrderedSet[C]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(set.Elements())
+}
+
+// UnmarshalJSON converts the JSON []byte to an [OrderedSet].
+func (set *OrderedSet[C]) UnmarshalJSON(data []byte) error {
+	var slice []C
+	if err := json.Unmarshal(data, &slice); err != nil {
+		return err
+	}
+	*set = *NewOrderedSet(slice...)
+	return nil
+}
+
+// MarshalYAML converts the [OrderedSet] to a YAML array, in insertion order.
+func (set OrderedSet[C]) MarshalYAML() (interface{}, error) {
+	return set.Elements(), nil
+}
+
+// UnmarshalYAML converts the YAML [yaml.Node] (representing a YAML list) to an [OrderedSet].
+func (set *OrderedSet[C]) UnmarshalYAML(value *yaml.Node) error {
+	var slice []C
+	if err := value.Decode(&slice); err != nil {
+		return err
+	}
+	*set = *NewOrderedSet(slice...)
+	return nil
+}
+
+// formatElements writes elements the way sets are printed, like "{ 1, 2, 3 }".
+func formatElements[C any](elements []C) string {
+	var sb strings.Builder
+	sb.WriteRune('{')
+	for i, e := range elements {
+		if i != 0 {
+			sb.WriteRune(',')
+		}
+		sb.WriteRune(' ')
+		fmt.Fprint(&sb, e)
+	}
+	sb.WriteRune(' ')
+	sb.WriteRune('}')
+	return sb.String()
+}
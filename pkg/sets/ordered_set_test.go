@@ -0,0 +1,96 @@
+package sets_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/toolvox/utilgo/pkg/sets"
+	"github.com/toolvox/utilgo/test"
+	test_sets "github.com/toolvox/utilgo/test/sets_test"
+)
+
+func Test_OrderedSet(t *testing.T) {
+	t.Run("int", func(t *testing.T) {
+		test_sets.Run_Test_Set(t,
+			test.TestConstructorFor[*sets.OrderedSet[int]]{
+				NewFunc: func() *sets.OrderedSet[int] {
+					return sets.NewOrderedSet[int]()
+				},
+			},
+			test.TestDataFor[int]{
+				ElementFunc: func(i int) int { return i },
+			},
+			10_000,
+		)
+	})
+	t.Run("string", func(t *testing.T) {
+		test_sets.Run_Test_Set(t,
+			test.TestConstructorFor[*sets.OrderedSet[string]]{
+				NewFunc: func() *sets.OrderedSet[string] {
+					return sets.NewOrderedSet[string]()
+				},
+			},
+			test.TestDataFor[string]{
+				ElementFunc: func(i int) string { return fmt.Sprint(i) },
+			},
+			1_000,
+		)
+	})
+
+	t.Run("Order", func(t *testing.T) {
+		must := require.New(t)
+		testSet := sets.NewOrderedSet(5, 3, 9, 3, 1)
+		must.Equal([]int{5, 3, 9, 1}, testSet.Elements())
+		must.Equal("{ 5, 3, 9, 1 }", testSet.String())
+
+		testSet.Remove(3, 7)
+		testSet.Add(3, 5)
+		must.Equal([]int{5, 9, 1, 3}, testSet.Elements())
+		must.Equal(2, testSet.IndexOf(1))
+		must.Equal(-1, testSet.IndexOf(7))
+
+		var zero sets.OrderedSet[int]
+		zero.Add(2, 1)
+		must.Equal("{ 2, 1 }", zero.String())
+	})
+
+	t.Run("Operations", func(t *testing.T) {
+		must := require.New(t)
+		a, b := sets.NewOrderedSet(4, 1, 3), sets.NewOrderedSet(3, 2, 4)
+		must.Equal([]int{4, 1, 3, 2}, a.Union(b).Elements())
+		must.Equal([]int{4, 1, 3, 5}, a.UnionWith(5, 1).Elements())
+		must.Equal([]int{4, 3}, a.Intersection(b).Elements())
+		must.Equal([]int{1, 3}, a.IntersectionWith(3, 1).Elements())
+		must.Equal([]int{1}, a.Difference(b).Elements())
+		must.Equal([]int{4, 3}, a.DifferenceWith(1).Elements())
+
+		threeWay := a.ThreeWay(b)
+		must.Equal([]int{4, 3}, threeWay[0].Elements())
+		must.Equal([]int{1}, threeWay[1].Elements())
+		must.Equal([]int{2}, threeWay[2].Elements())
+		must.Equal([]int{1, 2}, a.SymmetricDifference(b).Elements())
+	})
+}
+
+func Test_OrderedSet_Encoding(t *testing.T) {
+	must := require.New(t)
+	testSet := sets.NewOrderedSet("hello", "goodbye", "salute")
+
+	bs, err := json.Marshal(testSet)
+	must.NoError(err)
+	must.Equal(`["hello","goodbye","salute"]`, string(bs))
+	var jsonSet sets.OrderedSet[string]
+	must.NoError(json.Unmarshal(bs, &jsonSet))
+	must.Equal(testSet.Elements(), jsonSet.Elements())
+
+	bs, err = yaml.Marshal(testSet)
+	must.NoError(err)
+	must.Equal("- hello\n- goodbye\n- salute\n", string(bs))
+	var yamlSet *sets.OrderedSet[string]
+	must.NoError(yaml.Unmarshal(bs, &yamlSet))
+	must.Equal(testSet.Elements(), yamlSet.Elements())
+}
@@ -0,0 +1,193 @@
+package sets
+
+import (
+	"encoding/json"
+	"iter"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/toolvox/utilgo/api"
+	"github.com/toolvox/utilgo/pkg/maputil"
+)
+
+// Persistent represents an immutable set of elements of type C, based on a [maputil.Persistent] map.
+//
+// Adding and removing elements return a new version of the set, sharing most of its structure with the old one.
+// Versions never change, so they can be passed between goroutines and kept as snapshots without copying.
+// It implements [api.ReadOnlySet], and can be compared and combined with any other set.
+//
+// The zero value is an empty set.
+type Persistent[C comparable] struct {
+	m maputil.Persistent[C, Unit]
+}
+
+// NewPersistent initializes a new [Persistent] set with the given elements, ensuring uniqueness.
+func NewPersistent[C comparable](elements ...C) Persistent[C] {
+	return Persistent[C]{}.Add(elements...)
+}
+
+// NewPersistentFromSet initializes a new [Persistent] set with the elements of the set.
+func NewPersistentFromSet[C comparable](set api.ReadOnlySet[C]) Persistent[C] {
+	var result Persistent[C]
+	for e := range allOf(set) {
+		result.m = result.m.Set(e, U)
+	}
+	return result
+}
+
+// String returns the string representation of the [Persistent] set, in sorted order like a [Set].
+func (set Persistent[C]) String() string { return formatElements(sortElements(set.Elements())) }
+
+// Len counts the elements in the [Persistent] set.
+func (set Persistent[C]) Len() int { return set.m.Len() }
+
+// Elements returns the unique elements of the [Persistent] set.
+func (set Persistent[C]) Elements() []C {
+	elements := make([]C, 0, set.m.Len())
+	for e := range set.m.Keys() {
+		elements = append(elements, e)
+	}
+	return elements
+}
+
+// All iterates over the elements of the [Persistent] set.
+func (set Persistent[C]) All() iter.Seq[C] { return set.m.Keys() }
+
+// Contains checked whether all elements are in the [Persistent] set.
+func (set Persistent[C]) Contains(elements ...C) bool {
+	for _, e := range elements {
+		if !set.m.Has(e) {
+			return false
+		}
+	}
+	return true
+}
+
+// Add returns a new version of the [Persistent] set with the elements added.
+func (set Persistent[C]) Add(elements ...C) Persistent[C] {
+	for _, e := range elements {
+		if !set.m.Has(e) {
+			set.m = set.m.Set(e, U)
+		}
+	}
+	return set
+}
+
+// Remove returns a new version of the [Persistent] set without the elements.
+func (set Persistent[C]) Remove(elements ...C) Persistent[C] {
+	return Persistent[C]{m: set.m.Remove(elements...)}
+}
+
+// Set returns the elements of the [Persistent] set as a mutable [Set].
+func (set Persistent[C]) Set() Set[C] {
+	result := make(Set[C], set.m.Len())
+	for e := range set.m.Keys() {
+		result[e] = U
+	}
+	return result
+}
+
+// ContainsAny checks whether any of the elements is in the [Persistent] set.
+func (set Persistent[C]) ContainsAny(elements ...C) bool { return containsAny(set, elements) }
+
+// IsSubset checks whether all elements of the [Persistent] set are in the other set.
+func (set Persistent[C]) IsSubset(other api.ReadOnlySet[C]) bool { return isSubset(set, other) }
+
+// IsSuperset checks whether all elements of the other set are in the [Persistent] set.
+func (set Persistent[C]) IsSuperset(other api.ReadOnlySet[C]) bool { return isSuperset(set, other) }
+
+// Equal checks whether the [Persistent] set and the other set have the same elements.
+func (set Persistent[C]) Equal(other api.ReadOnlySet[C]) bool { return isEqual(set, other) }
+
+// Disjoint checks whether the [Persistent] set and the other set have no elements in common.
+func (set Persistent[C]) Disjoint(other api.ReadOnlySet[C]) bool { return isDisjoint(set, other) }
+
+// Union combines two sets into a new one containing elements from both, adding the smaller to the larger.
+func (set Persistent[C]) Union(other Persistent[C]) Persistent[C] {
+	if set.Len() < other.Len() {
+		set, other = other, set
+	}
+	for e := range other.All() {
+		if !set.m.Has(e) {
+			set.m = set.m.Set(e, U)
+		}
+	}
+	return set
+}
+
+// UnionWith adds multiple elements to a new version of the set and returns it.
+func (set Persistent[C]) UnionWith(elements ...C) Persistent[C] { return set.Add(elements...) }
+
+// Intersection creates a set of elements common to both sets.
+func (set Persistent[C]) Intersection(other Persistent[C]) Persistent[C] {
+	if set.Len() > other.Len() {
+		set, other = other, set
+	}
+	var result Persistent[C]
+	for e := range set.All() {
+		if other.m.Has(e) {
+			result.m = result.m.Set(e, U)
+		}
+	}
+	return result
+}
+
+// IntersectionWith forms a set from common elements of the set and the provided elements.
+func (set Persistent[C]) IntersectionWith(elements ...C) Persistent[C] {
+	return set.Intersection(NewPersistent(elements...))
+}
+
+// Difference creates a set of elements in the first set but not in the second.
+func (set Persistent[C]) Difference(other Persistent[C]) Persistent[C] {
+	for e := range other.All() {
+		set.m = set.m.Remove(e)
+	}
+	return set
+}
+
+// DifferenceWith creates a set of the elements of the set that are not among the provided elements.
+func (set Persistent[C]) DifferenceWith(elements ...C) Persistent[C] { return set.Remove(elements...) }
+
+// SymmetricDifference creates a set of elements in exactly one of the sets.
+func (set Persistent[C]) SymmetricDifference(other Persistent[C]) Persistent[C] {
+	return set.Difference(other).Union(other.Difference(set))
+}
+
+// ThreeWay splits elements into three sets: common, only in the first set, and only in the second set.
+func (set Persistent[C]) ThreeWay(other Persistent[C]) [3]Persistent[C] {
+	return [3]Persistent[C]{
+		set.Intersection(other),
+		set.Difference(other),
+		other.Difference(set),
+	}
+}
+
+// MarshalJSON converts the [Persistent] set to a JSON array, in sorted order like a [Set].
+func (set Persistent[C]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(sortElements(set.Elements()))
+}
+
+// UnmarshalJSON converts the JSON []byte to a [Persistent] set, replacing the version it points to.
+func (set *Persistent[C]) UnmarshalJSON(data []byte) error {
+	var slice []C
+	if err := json.Unmarshal(data, &slice); err != nil {
+		return err
+	}
+	*set = NewPersistent(slice...)
+	return nil
+}
+
+// MarshalYAML converts the [Persistent] set to a YAML array, in sorted order like a [Set].
+func (set Persistent[C]) MarshalYAML() (interface{}, error) {
+	return sortElements(set.Elements()), nil
+}
+
+// UnmarshalYAML converts the YAML [yaml.Node] (representing a YAML list) to a [Persistent] set, replacing the version it points to.
+func (set *Persistent[C]) UnmarshalYAML(value *yaml.Node) error {
+	var slice []C
+	if err := value.Decode(&slice); err != nil {
+		return err
+	}
+	*set = NewPersistent(slice...)
+	return nil
+}
@@ -0,0 +1,115 @@
+package sets_test
+
+import (
+	"encoding/json"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/toolvox/utilgo/api"
+	"github.com/toolvox/utilgo/pkg/sets"
+)
+
+func Test_Persistent(t *testing.T) {
+	t.Run("Versions", func(t *testing.T) {
+		must := require.New(t)
+		var empty sets.Persistent[int]
+		must.Equal(0, empty.Len())
+		must.Equal("{ }", empty.String())
+
+		v1 := empty.Add(3, 1, 2, 1)
+		v2 := v1.Add(4).Remove(1)
+		must.Equal(0, empty.Len())
+		must.Equal("{ 1, 2, 3 }", v1.String())
+		must.Equal("{ 2, 3, 4 }", v2.String())
+		must.True(v1.Contains(1, 2, 3))
+		must.False(v1.Contains(4))
+		must.True(v2.ContainsAny(1, 4))
+		must.ElementsMatch([]int{2, 3, 4}, v2.Elements())
+		must.Equal(sets.NewSet(2, 3, 4), v2.Set())
+	})
+
+	t.Run("Snapshots", func(t *testing.T) {
+		must := require.New(t)
+		snapshot := sets.NewPersistent[int]()
+		for i := range 1_000 {
+			snapshot = snapshot.Add(i)
+		}
+		var wg sync.WaitGroup
+		for range 4 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range 1_000 {
+					if !snapshot.Contains(i) {
+						panic(i)
+					}
+				}
+			}()
+		}
+		current := snapshot
+		for i := range 1_000 {
+			current = current.Remove(i)
+		}
+		wg.Wait()
+		must.Equal(0, current.Len())
+		must.Equal(1_000, snapshot.Len())
+	})
+
+	t.Run("Algebra", func(t *testing.T) {
+		must := require.New(t)
+		a, b := sets.NewPersistent(1, 2, 3, 4), sets.NewPersistent(3, 4, 5)
+		must.True(a.Union(b).Equal(sets.NewSet(1, 2, 3, 4, 5)))
+		must.True(a.UnionWith(9).Equal(sets.NewSet(1, 2, 3, 4, 9)))
+		must.True(a.Intersection(b).Equal(sets.NewSet(3, 4)))
+		must.True(a.IntersectionWith(1, 9).Equal(sets.NewSet(1)))
+		must.True(a.Difference(b).Equal(sets.NewSet(1, 2)))
+		must.True(a.DifferenceWith(1, 9).Equal(sets.NewSet(2, 3, 4)))
+		must.True(a.SymmetricDifference(b).Equal(sets.NewSet(1, 2, 5)))
+		blr := a.ThreeWay(b)
+		must.True(blr[0].Equal(sets.NewSet(3, 4)))
+		must.True(blr[1].Equal(sets.NewSet(1, 2)))
+		must.True(blr[2].Equal(sets.NewSet(5)))
+		must.Equal("{ 1, 2, 3, 4 }", a.String())
+		must.Equal("{ 3, 4, 5 }", b.String())
+	})
+
+	t.Run("Interop", func(t *testing.T) {
+		must := require.New(t)
+		persistent := sets.NewPersistent(1, 2, 3)
+		tiny := sets.NewTinySet(3, 4)
+		must.True(persistent.IsSubset(sets.NewSet(1, 2, 3, 4)))
+		must.True(persistent.IsSuperset(sets.NewOrderedSet(2, 3)))
+		must.True(persistent.Equal(sets.NewBitSet(3, 2, 1)))
+		must.False(persistent.Disjoint(tiny))
+		must.True(sets.NewTinySet(1, 2).IsSubset(persistent))
+
+		must.Equal(sets.NewSet(1, 2, 3, 4), sets.Union[int](persistent, tiny))
+		must.Equal(sets.NewSet(3), sets.Intersection[int](persistent, tiny))
+
+		var readOnly api.ReadOnlySet[int] = persistent
+		must.True(sets.NewPersistentFromSet(tiny).Equal(tiny))
+		must.True(sets.NewPersistentFromSet(readOnly).Equal(persistent))
+	})
+}
+
+func Test_Persistent_Encoding(t *testing.T) {
+	must := require.New(t)
+	set := sets.NewPersistent("c", "a", "b")
+
+	bs, err := json.Marshal(set)
+	must.NoError(err)
+	must.Equal(`["a","b","c"]`, string(bs))
+	jsonSet := sets.NewPersistent("z")
+	must.NoError(json.Unmarshal(bs, &jsonSet))
+	must.True(jsonSet.Equal(set))
+
+	bs, err = yaml.Marshal(set)
+	must.NoError(err)
+	must.Equal("- a\n- b\n- c\n", string(bs))
+	yamlSet := sets.NewPersistent("z")
+	must.NoError(yaml.Unmarshal(bs, &yamlSet))
+	must.True(yamlSet.Equal(set))
+}
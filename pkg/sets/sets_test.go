@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/toolvox/utilgo/api"
 	"github.com/toolvox/utilgo/pkg/sets"
 )
 
@@ -61,3 +62,31 @@ func Benchmark_Add(b *testing.B) {
 		})
 	}
 }
+
+func Benchmark_AdaptiveSet(b *testing.B) {
+	benchSet := func(b *testing.B, n int, newSet func() api.BasicSet[int]) {
+		for i := 0; i < b.N; i++ {
+			testSet := newSet()
+			for v := range n {
+				testSet.Add(v)
+			}
+			for v := range 2 * n {
+				testSet.Contains(v)
+			}
+		}
+	}
+
+	for _, n := range []int{2, 8, 16, 31, 32, 33, 48, 64, 96, 256, 1024} {
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			b.Run("Set", func(b *testing.B) {
+				benchSet(b, n, func() api.BasicSet[int] { return sets.NewSet[int]() })
+			})
+			b.Run("TinySet", func(b *testing.B) {
+				benchSet(b, n, func() api.BasicSet[int] { return sets.NewTinySet[int]() })
+			})
+			b.Run("AdaptiveSet", func(b *testing.B) {
+				benchSet(b, n, func() api.BasicSet[int] { return sets.NewAdaptiveSet[int]() })
+			})
+		})
+	}
+}
@@ -0,0 +1,260 @@
+package sets
+
+import (
+	"cmp"
+	"encoding/json"
+	"iter"
+	"slices"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/toolvox/utilgo/api"
+)
+
+// SortedSet represents a set of ordered elements of type C, kept sorted in a slice.
+// It supports range queries and finding the nearest elements to a value.
+type SortedSet[C cmp.Ordered] struct {
+	elements []C
+}
+
+// NewSortedSet initializes a new [SortedSet] with the given elements, ensuring uniqueness.
+func NewSortedSet[C cmp.Ordered](elements ...C) *SortedSet[C] {
+	sorted := slices.Clone(elements)
+	slices.Sort(sorted)
+	return &SortedSet[C]{elements: slices.Compact(sorted)}
+}
+
+// String returns the string representation of the [SortedSet], in ascending order.
+func (set SortedSet[C]) String() string { return formatElements(set.elements) }
+
+// Len counts the elements in the [SortedSet].
+func (set SortedSet[C]) Len() int { return len(set.elements) }
+
+// Elements returns the unique elements of the [SortedSet], in ascending order.
+func (set SortedSet[C]) Elements() []C {
+	return append(make([]C, 0, len(set.elements)), set.elements...)
+}
+
+// Add unique elements to the [SortedSet].
+// Repeated elements will be discarded.
+func (set *SortedSet[C]) Add(elements ...C) {
+	for _, e := range elements {
+		if i, found := slices.BinarySearch(set.elements, e); !found {
+			set.elements = slices.Insert(set.elements, i, e)
+		}
+	}
+}
+
+// Contains checked whether all elements are in the [SortedSet].
+func (set SortedSet[C]) Contains(elements ...C) bool {
+	for _, e := range elements {
+		if _, found := slices.BinarySearch(set.elements, e); !found {
+			return false
+		}
+	}
+	return true
+}
+
+// Remove any existing elements from the [SortedSet].
+func (set *SortedSet[C]) Remove(elements ...C) {
+	for _, e := range elements {
+		if i, found := slices.BinarySearch(set.elements, e); found {
+			set.elements = slices.Delete(set.elements, i, i+1)
+		}
+	}
+}
+
+// Min returns the smallest element of the [SortedSet], and false if the set is empty.
+func (set SortedSet[C]) Min() (C, bool) {
+	if len(set.elements) == 0 {
+		var zero C
+		return zero, false
+	}
+	return set.elements[0], true
+}
+
+// Max returns the largest element of the [SortedSet], and false if the set is empty.
+func (set SortedSet[C]) Max() (C, bool) {
+	if len(set.elements) == 0 {
+		var zero C
+		return zero, false
+	}
+	return set.elements[len(set.elements)-1], true
+}
+
+// Floor returns the largest element less than or equal to value, and false if there is none.
+func (set SortedSet[C]) Floor(value C) (C, bool) {
+	i, found := slices.BinarySearch(set.elements, value)
+	if found {
+		return set.elements[i], true
+	}
+	if i == 0 {
+		var zero C
+		return zero, false
+	}
+	return set.elements[i-1], true
+}
+
+// Ceiling returns the smallest element greater than or equal to value, and false if there is none.
+func (set SortedSet[C]) Ceiling(value C) (C, bool) {
+	i, _ := slices.BinarySearch(set.elements, value)
+	if i == len(set.elements) {
+		var zero C
+		return zero, false
+	}
+	return set.elements[i], true
+}
+
+// Range returns the elements between from and to, inclusive, in ascending order.
+func (set SortedSet[C]) Range(from, to C) []C {
+	lo, _ := slices.BinarySearch(set.elements, from)
+	hi, found := slices.BinarySearch(set.elements, to)
+	if found {
+		hi++
+	}
+	if hi <= lo {
+		return nil
+	}
+	return slices.Clone(set.elements[lo:hi])
+}
+
+// Union combines two sets into a new one containing elements from both.
+func (set SortedSet[C]) Union(other *SortedSet[C]) *SortedSet[C] {
+	result := make([]C, 0, len(set.elements)+len(other.elements))
+	a, b := set.elements, other.elements
+	for len(a) > 0 && len(b) > 0 {
+		switch c := cmp.Compare(a[0], b[0]); {
+		case c < 0:
+			result, a = append(result, a[0]), a[1:]
+		case c > 0:
+			result, b = append(result, b[0]), b[1:]
+		default:
+			result, a, b = append(result, a[0]), a[1:], b[1:]
+		}
+	}
+	result = append(append(result, a...), b...)
+	return &SortedSet[C]{elements: result}
+}
+
+// UnionWith adds multiple elements to a copy of the set and returns the resulting set.
+func (set SortedSet[C]) UnionWith(elements ...C) *SortedSet[C] {
+	return set.Union(NewSortedSet(elements...))
+}
+
+// Intersection creates a set of elements common to both sets.
+func (set SortedSet[C]) Intersection(other *SortedSet[C]) *SortedSet[C] {
+	var result []C
+	a, b := set.elements, other.elements
+	for len(a) > 0 && len(b) > 0 {
+		switch c := cmp.Compare(a[0], b[0]); {
+		case c < 0:
+			a = a[1:]
+		case c > 0:
+			b = b[1:]
+		default:
+			result, a, b = append(result, a[0]), a[1:], b[1:]
+		}
+	}
+	return &SortedSet[C]{elements: result}
+}
+
+// IntersectionWith forms a set from common elements of the set and the provided elements.
+func (set SortedSet[C]) IntersectionWith(elements ...C) *SortedSet[C] {
+	return set.Intersection(NewSortedSet(elements...))
+}
+
+// Difference creates a set of elements in the first set but not in the second.
+func (set SortedSet[C]) Difference(other *SortedSet[C]) *SortedSet[C] {
+	var result []C
+	a, b := set.elements, other.elements
+	for len(a) > 0 && len(b) > 0 {
+		switch c := cmp.Compare(a[0], b[0]); {
+		case c < 0:
+			result, a = append(result, a[0]), a[1:]
+		case c > 0:
+			b = b[1:]
+		default:
+			a, b = a[1:], b[1:]
+		}
+	}
+	result = append(result, a...)
+	return &SortedSet[C]{elements: result}
+}
+
+// DifferenceWith creates a set of the elements of the set that are not among the provided elements.
+func (set SortedSet[C]) DifferenceWith(elements ...C) *SortedSet[C] {
+	return set.Difference(NewSortedSet(elements...))
+}
+
+// ThreeWay splits elements into three sets: common, only in the first set, and only in the second set.
+func (set SortedSet[C]) ThreeWay(other *SortedSet[C]) [3]*SortedSet[C] {
+	return [3]*SortedSet[C]{
+		set.Intersection(other),
+		set.Difference(other),
+		other.Difference(&set),
+	}
+}
+
+// SymmetricDifference creates a set of elements in exactly one of the sets.
+func (set SortedSet[C]) SymmetricDifference(other *SortedSet[C]) *SortedSet[C] {
+	return set.Difference(other).Union(other.Difference(&set))
+}
+
+// All iterates over the elements of the [SortedSet], in ascending order.
+func (set SortedSet[C]) All() iter.Seq[C] { return slices.Values(set.elements) }
+
+// Clear removes all elements from the [SortedSet].
+func (set *SortedSet[C]) Clear() {
+	clear(set.elements)
+	set.elements = set.elements[:0]
+}
+
+// Clone returns a copy of the [SortedSet].
+func (set SortedSet[C]) Clone() api.Set[C] {
+	return &SortedSet[C]{elements: slices.Clone(set.elements)}
+}
+
+// ContainsAny checks whether any of the elements is in the [SortedSet].
+func (set SortedSet[C]) ContainsAny(elements ...C) bool { return containsAny(set, elements) }
+
+// IsSubset checks whether all elements of the [SortedSet] are in the other set.
+func (set SortedSet[C]) IsSubset(other api.ReadOnlySet[C]) bool { return isSubset(set, other) }
+
+// IsSuperset checks whether all elements of the other set are in the [SortedSet].
+func (set SortedSet[C]) IsSuperset(other api.ReadOnlySet[C]) bool { return isSuperset(set, other) }
+
+// Equal checks whether the [SortedSet] and the other set have the same elements.
+func (set SortedSet[C]) Equal(other api.ReadOnlySet[C]) bool { return isEqual(set, other) }
+
+// Disjoint checks whether the [SortedSet] and the other set have no elements in common.
+func (set SortedSet[C]) Disjoint(other api.ReadOnlySet[C]) bool { return isDisjoint(set, other) }
+
+// MarshalJSON converts the [SortedSet] to a JSON array, in ascending order.
+func (set SortedSet[C]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(set.Elements())
+}
+
+// UnmarshalJSON converts the JSON []byte to a [SortedSet].
+func (set *SortedSet[C]) UnmarshalJSON(data []byte) error {
+	var slice []C
+	if err := json.Unmarshal(data, &slice); err != nil {
+		return err
+	}
+	*set = *NewSortedSet(slice...)
+	return nil
+}
+
+// MarshalYAML converts the [SortedSet] to a YAML array, in ascending order.
+func (set SortedSet[C]) MarshalYAML() (interface{}, error) {
+	return set.Elements(), nil
+}
+
+// UnmarshalYAML converts the YAML [yaml.Node] (representing a YAML list) to a [SortedSet].
+func (set *SortedSet[C]) UnmarshalYAML(value *yaml.Node) error {
+	var slice []C
+	if err := value.Decode(&slice); err != nil {
+		return err
+	}
+	*set = *NewSortedSet(slice...)
+	return nil
+}
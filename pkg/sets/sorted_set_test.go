@@ -0,0 +1,128 @@
+package sets_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/toolvox/utilgo/pkg/sets"
+	"github.com/toolvox/utilgo/test"
+	test_sets "github.com/toolvox/utilgo/test/sets_test"
+)
+
+func Test_SortedSet(t *testing.T) {
+	t.Run("int", func(t *testing.T) {
+		test_sets.Run_Test_Set(t,
+			test.TestConstructorFor[*sets.SortedSet[int]]{
+				NewFunc: func() *sets.SortedSet[int] {
+					return sets.NewSortedSet[int]()
+				},
+			},
+			test.TestDataFor[int]{
+				ElementFunc: func(i int) int { return i },
+			},
+			10_000,
+		)
+	})
+	t.Run("string", func(t *testing.T) {
+		test_sets.Run_Test_Set(t,
+			test.TestConstructorFor[*sets.SortedSet[string]]{
+				NewFunc: func() *sets.SortedSet[string] {
+					return sets.NewSortedSet[string]()
+				},
+			},
+			test.TestDataFor[string]{
+				ElementFunc: func(i int) string { return fmt.Sprint(i) },
+			},
+			1_000,
+		)
+	})
+
+	t.Run("Order", func(t *testing.T) {
+		must := require.New(t)
+		testSet := sets.NewSortedSet(5, 3, 9, 3, 1)
+		must.Equal([]int{1, 3, 5, 9}, testSet.Elements())
+		must.Equal("{ 1, 3, 5, 9 }", testSet.String())
+		testSet.Add(4, 10)
+		testSet.Remove(1, 2)
+		must.Equal([]int{3, 4, 5, 9, 10}, testSet.Elements())
+	})
+
+	t.Run("Queries", func(t *testing.T) {
+		must := require.New(t)
+		testSet := sets.NewSortedSet(10, 20, 30, 40)
+
+		tests := []struct {
+			value            int
+			floor, ceiling   int
+			hasFloor, hasCei bool
+		}{
+			{5, 0, 10, false, true},
+			{10, 10, 10, true, true},
+			{25, 20, 30, true, true},
+			{40, 40, 40, true, true},
+			{45, 40, 0, true, false},
+		}
+		for _, tt := range tests {
+			floor, ok := testSet.Floor(tt.value)
+			must.Equal(tt.hasFloor, ok, "floor %d", tt.value)
+			must.Equal(tt.floor, floor, "floor %d", tt.value)
+			ceiling, ok := testSet.Ceiling(tt.value)
+			must.Equal(tt.hasCei, ok, "ceiling %d", tt.value)
+			must.Equal(tt.ceiling, ceiling, "ceiling %d", tt.value)
+		}
+
+		must.Equal([]int{20, 30}, testSet.Range(15, 30))
+		must.Equal([]int{10, 20, 30, 40}, testSet.Range(0, 100))
+		must.Empty(testSet.Range(21, 29))
+		must.Empty(testSet.Range(30, 20))
+
+		minV, ok := testSet.Min()
+		must.True(ok)
+		must.Equal(10, minV)
+		maxV, ok := testSet.Max()
+		must.True(ok)
+		must.Equal(40, maxV)
+		_, ok = sets.NewSortedSet[int]().Min()
+		must.False(ok)
+	})
+
+	t.Run("Operations", func(t *testing.T) {
+		must := require.New(t)
+		a, b := sets.NewSortedSet(4, 1, 3), sets.NewSortedSet(3, 2, 4, 8)
+		must.Equal([]int{1, 2, 3, 4, 8}, a.Union(b).Elements())
+		must.Equal([]int{0, 1, 3, 4}, a.UnionWith(0, 1).Elements())
+		must.Equal([]int{3, 4}, a.Intersection(b).Elements())
+		must.Equal([]int{1, 3}, a.IntersectionWith(3, 1, 7).Elements())
+		must.Equal([]int{1}, a.Difference(b).Elements())
+		must.Equal([]int{3, 4}, a.DifferenceWith(1).Elements())
+
+		threeWay := a.ThreeWay(b)
+		must.Equal([]int{3, 4}, threeWay[0].Elements())
+		must.Equal([]int{1}, threeWay[1].Elements())
+		must.Equal([]int{2, 8}, threeWay[2].Elements())
+		must.Equal([]int{1, 2, 8}, a.SymmetricDifference(b).Elements())
+	})
+}
+
+func Test_SortedSet_Encoding(t *testing.T) {
+	must := require.New(t)
+	testSet := sets.NewSortedSet("hello", "goodbye", "salute")
+
+	bs, err := json.Marshal(testSet)
+	must.NoError(err)
+	must.Equal(`["goodbye","hello","salute"]`, string(bs))
+	var jsonSet sets.SortedSet[string]
+	must.NoError(json.Unmarshal([]byte(`["b","a","b"]`), &jsonSet))
+	must.Equal([]string{"a", "b"}, jsonSet.Elements())
+
+	bs, err = yaml.Marshal(testSet)
+	must.NoError(err)
+	must.Equal("- goodbye\n- hello\n- salute\n", string(bs))
+	var yamlSet *sets.SortedSet[string]
+	must.NoError(yaml.Unmarshal(bs, &yamlSet))
+	must.Equal(testSet.Elements(), yamlSet.Elements())
+}
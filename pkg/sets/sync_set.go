@@ -0,0 +1,215 @@
+package sets
+
+import (
+	"encoding/json"
+	"iter"
+	"maps"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/toolvox/utilgo/api"
+)
+
+// SyncSet is a [Set] guarded by a lock, safe for concurrent readers and writers.
+type SyncSet[C comparable] struct {
+	lock sync.RWMutex
+	set  Set[C]
+}
+
+// NewSyncSet initializes a new [SyncSet] with the given elements, ensuring uniqueness.
+func NewSyncSet[C comparable](elements ...C) *SyncSet[C] {
+	return &SyncSet[C]{set: NewSet(elements...)}
+}
+
+// String returns the string representation of the [SyncSet].
+func (set *SyncSet[C]) String() string {
+	set.lock.RLock()
+	defer set.lock.RUnlock()
+	return set.set.String()
+}
+
+// Len counts the elements in the [SyncSet].
+func (set *SyncSet[C]) Len() int {
+	set.lock.RLock()
+	defer set.lock.RUnlock()
+	return set.set.Len()
+}
+
+// Elements returns the unique elements of the [SyncSet].
+func (set *SyncSet[C]) Elements() []C {
+	set.lock.RLock()
+	defer set.lock.RUnlock()
+	return set.set.Elements()
+}
+
+// Add unique elements to the [SyncSet].
+// Repeated elements will be discarded.
+func (set *SyncSet[C]) Add(elements ...C) {
+	set.lock.Lock()
+	defer set.lock.Unlock()
+	if set.set == nil {
+		set.set = NewSet[C]()
+	}
+	set.set.Add(elements...)
+}
+
+// Contains checked whether all elements are in the [SyncSet].
+func (set *SyncSet[C]) Contains(elements ...C) bool {
+	set.lock.RLock()
+	defer set.lock.RUnlock()
+	return set.set.Contains(elements...)
+}
+
+// Remove any existing elements from the [SyncSet].
+func (set *SyncSet[C]) Remove(elements ...C) {
+	set.lock.Lock()
+	defer set.lock.Unlock()
+	set.set.Remove(elements...)
+}
+
+// AddIfAbsent adds the element unless it is already in the [SyncSet], and reports whether it was added.
+func (set *SyncSet[C]) AddIfAbsent(element C) bool {
+	set.lock.Lock()
+	defer set.lock.Unlock()
+	if set.set.Contains(element) {
+		return false
+	}
+	if set.set == nil {
+		set.set = NewSet[C]()
+	}
+	set.set.Add(element)
+	return true
+}
+
+// Snapshot returns a copy of the current elements of the [SyncSet] as a [Set].
+func (set *SyncSet[C]) Snapshot() Set[C] {
+	set.lock.RLock()
+	defer set.lock.RUnlock()
+	if set.set == nil {
+		return NewSet[C]()
+	}
+	return maps.Clone(set.set)
+}
+
+// Update calls fn with the underlying [Set] while holding the write lock, for compound changes.
+// The set must not be retained after fn returns.
+func (set *SyncSet[C]) Update(fn func(set Set[C])) {
+	set.lock.Lock()
+	defer set.lock.Unlock()
+	if set.set == nil {
+		set.set = NewSet[C]()
+	}
+	fn(set.set)
+}
+
+// Union combines two sets into a new one containing elements from both.
+//
+// Like the rest of the family, it works on snapshots, taken one set at a time.
+func (set *SyncSet[C]) Union(other *SyncSet[C]) *SyncSet[C] {
+	return &SyncSet[C]{set: set.Snapshot().Union(other.Snapshot())}
+}
+
+// UnionWith adds multiple elements to a copy of the set and returns the resulting set.
+func (set *SyncSet[C]) UnionWith(elements ...C) *SyncSet[C] {
+	return &SyncSet[C]{set: set.Snapshot().UnionWith(elements...)}
+}
+
+// Intersection creates a set of elements common to both sets.
+func (set *SyncSet[C]) Intersection(other *SyncSet[C]) *SyncSet[C] {
+	return &SyncSet[C]{set: set.Snapshot().Intersection(other.Snapshot())}
+}
+
+// IntersectionWith forms a set from common elements of the set and the provided elements.
+func (set *SyncSet[C]) IntersectionWith(elements ...C) *SyncSet[C] {
+	return &SyncSet[C]{set: set.Snapshot().IntersectionWith(elements...)}
+}
+
+// Difference creates a set of elements in the first set but not in the second.
+func (set *SyncSet[C]) Difference(other *SyncSet[C]) *SyncSet[C] {
+	return &SyncSet[C]{set: set.Snapshot().Difference(other.Snapshot())}
+}
+
+// DifferenceWith creates a set of the elements of the set that are not among the provided elements.
+func (set *SyncSet[C]) DifferenceWith(elements ...C) *SyncSet[C] {
+	return &SyncSet[C]{set: set.Snapshot().DifferenceWith(elements...)}
+}
+
+// ThreeWay splits elements into three sets: common, only in the first set, and only in the second set.
+func (set *SyncSet[C]) ThreeWay(other *SyncSet[C]) [3]*SyncSet[C] {
+	blr := set.Snapshot().ThreeWay(other.Snapshot())
+	return [3]*SyncSet[C]{{set: blr[0]}, {set: blr[1]}, {set: blr[2]}}
+}
+
+// SymmetricDifference creates a set of elements in exactly one of the sets.
+func (set *SyncSet[C]) SymmetricDifference(other *SyncSet[C]) *SyncSet[C] {
+	return &SyncSet[C]{set: set.Snapshot().SymmetricDifference(other.Snapshot())}
+}
+
+// All iterates over a snapshot of the elements of the [SyncSet], so the set may change while iterating.
+func (set *SyncSet[C]) All() iter.Seq[C] { return set.Snapshot().All() }
+
+// Clear removes all elements from the [SyncSet].
+func (set *SyncSet[C]) Clear() {
+	set.lock.Lock()
+	defer set.lock.Unlock()
+	clear(set.set)
+}
+
+// Clone returns a copy of the [SyncSet].
+func (set *SyncSet[C]) Clone() api.Set[C] { return &SyncSet[C]{set: set.Snapshot()} }
+
+// ContainsAny checks whether any of the elements is in the [SyncSet].
+func (set *SyncSet[C]) ContainsAny(elements ...C) bool {
+	set.lock.RLock()
+	defer set.lock.RUnlock()
+	return set.set.ContainsAny(elements...)
+}
+
+// IsSubset checks whether all elements of the [SyncSet] are in the other set.
+func (set *SyncSet[C]) IsSubset(other api.ReadOnlySet[C]) bool { return set.Snapshot().IsSubset(other) }
+
+// IsSuperset checks whether all elements of the other set are in the [SyncSet].
+func (set *SyncSet[C]) IsSuperset(other api.ReadOnlySet[C]) bool {
+	return set.Snapshot().IsSuperset(other)
+}
+
+// Equal checks whether the [SyncSet] and the other set have the same elements.
+func (set *SyncSet[C]) Equal(other api.ReadOnlySet[C]) bool { return set.Snapshot().Equal(other) }
+
+// Disjoint checks whether the [SyncSet] and the other set have no elements in common.
+func (set *SyncSet[C]) Disjoint(other api.ReadOnlySet[C]) bool { return set.Snapshot().Disjoint(other) }
+
+// MarshalJSON converts the [SyncSet] to a JSON array.
+func (set *SyncSet[C]) MarshalJSON() ([]byte, error) {
+	return json.Marshal(set.Snapshot())
+}
+
+// UnmarshalJSON converts the JSON []byte to a [SyncSet].
+func (set *SyncSet[C]) UnmarshalJSON(data []byte) error {
+	var decoded Set[C]
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	set.lock.Lock()
+	defer set.lock.Unlock()
+	set.set = decoded
+	return nil
+}
+
+// MarshalYAML converts the [SyncSet] to a YAML array.
+func (set *SyncSet[C]) MarshalYAML() (interface{}, error) {
+	return set.Snapshot().MarshalYAML()
+}
+
+// UnmarshalYAML converts the YAML [yaml.Node] (representing a YAML list) to a [SyncSet].
+func (set *SyncSet[C]) UnmarshalYAML(value *yaml.Node) error {
+	var decoded Set[C]
+	if err := value.Decode(&decoded); err != nil {
+		return err
+	}
+	set.lock.Lock()
+	defer set.lock.Unlock()
+	set.set = decoded
+	return nil
+}
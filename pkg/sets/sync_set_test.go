@@ -0,0 +1,119 @@
+package sets_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	"github.com/toolvox/utilgo/pkg/sets"
+	"github.com/toolvox/utilgo/test"
+	test_sets "github.com/toolvox/utilgo/test/sets_test"
+)
+
+func Test_SyncSet(t *testing.T) {
+	t.Run("int", func(t *testing.T) {
+		test_sets.Run_Test_Set(t,
+			test.TestConstructorFor[*sets.SyncSet[int]]{
+				NewFunc: func() *sets.SyncSet[int] {
+					return sets.NewSyncSet[int]()
+				},
+			},
+			test.TestDataFor[int]{
+				ElementFunc: func(i int) int { return i },
+			},
+			10_000,
+		)
+	})
+	t.Run("string", func(t *testing.T) {
+		test_sets.Run_Test_Set(t,
+			test.TestConstructorFor[*sets.SyncSet[string]]{
+				NewFunc: func() *sets.SyncSet[string] {
+					return sets.NewSyncSet[string]()
+				},
+			},
+			test.TestDataFor[string]{
+				ElementFunc: func(i int) string { return fmt.Sprint(i) },
+			},
+			10_000,
+		)
+	})
+
+	t.Run("Concurrent", func(t *testing.T) {
+		must := require.New(t)
+		var testSet sets.SyncSet[int]
+		var added sync.Map
+		var duplicates atomic.Int32
+		var wg sync.WaitGroup
+		for w := range 8 {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for i := range 1_000 {
+					testSet.Add(w*1_000 + i)
+					if testSet.AddIfAbsent(i) {
+						if _, loaded := added.LoadOrStore(i, w); loaded {
+							duplicates.Add(1)
+						}
+					}
+					testSet.Contains(i)
+					if i%100 == 0 {
+						_ = testSet.Union(sets.NewSyncSet(i))
+					}
+				}
+			}()
+		}
+		wg.Wait()
+		must.Zero(duplicates.Load())
+		must.Equal(8_000, testSet.Len())
+
+		testSet.Update(func(set sets.Set[int]) {
+			for v := range set {
+				if v%2 == 1 {
+					set.Remove(v)
+				}
+			}
+		})
+		must.Equal(4_000, testSet.Len())
+		must.Equal(4_000, testSet.Snapshot().Len())
+	})
+
+	t.Run("Operations", func(t *testing.T) {
+		must := require.New(t)
+		a, b := sets.NewSyncSet(4, 1, 3), sets.NewSyncSet(3, 2, 4)
+		must.ElementsMatch([]int{1, 2, 3, 4}, a.Union(b).Elements())
+		must.ElementsMatch([]int{1, 3, 4, 5}, a.UnionWith(5).Elements())
+		must.ElementsMatch([]int{3, 4}, a.Intersection(b).Elements())
+		must.ElementsMatch([]int{1}, a.IntersectionWith(1, 7).Elements())
+		must.ElementsMatch([]int{1}, a.Difference(b).Elements())
+		must.ElementsMatch([]int{3, 4}, a.DifferenceWith(1).Elements())
+
+		threeWay := a.ThreeWay(b)
+		must.ElementsMatch([]int{3, 4}, threeWay[0].Elements())
+		must.ElementsMatch([]int{1}, threeWay[1].Elements())
+		must.ElementsMatch([]int{2}, threeWay[2].Elements())
+		must.ElementsMatch([]int{1, 2}, a.SymmetricDifference(b).Elements())
+		must.True(a.ThreeWay(a)[0].Contains(1, 3, 4))
+	})
+}
+
+func Test_SyncSet_Encoding(t *testing.T) {
+	must := require.New(t)
+	testSet := sets.NewSyncSet("hello", "goodbye", "salute")
+
+	bs, err := json.Marshal(testSet)
+	must.NoError(err)
+	var jsonSet sets.SyncSet[string]
+	must.NoError(json.Unmarshal(bs, &jsonSet))
+	must.ElementsMatch(testSet.Elements(), jsonSet.Elements())
+
+	bs, err = yaml.Marshal(testSet)
+	must.NoError(err)
+	var yamlSet sets.SyncSet[string]
+	must.NoError(yaml.Unmarshal(bs, &yamlSet))
+	must.ElementsMatch(testSet.Elements(), yamlSet.Elements())
+}
@@ -4,10 +4,13 @@ package sets
 import (
 	"encoding/json"
 	"fmt"
+	"iter"
+	"slices"
 	"strings"
 
 	"gopkg.in/yaml.v3"
 
+	"github.com/toolvox/utilgo/api"
 	"github.com/toolvox/utilgo/pkg/sliceutil"
 )
 
@@ -138,6 +141,40 @@ func (tinySet TinySet[C]) DifferenceWith(elements ...C) TinySet[C] {
 	return tinySet.Difference(other)
 }
 
+// SymmetricDifference returns a new [TinySet] containing elements present in exactly one of the sets.
+func (tinySet TinySet[C]) SymmetricDifference(other TinySet[C]) TinySet[C] {
+	return tinySet.Difference(other).Union(other.Difference(tinySet))
+}
+
+// All iterates over the elements of the [TinySet].
+func (tinySet TinySet[C]) All() iter.Seq[C] { return slices.Values(tinySet) }
+
+// Clear removes all elements from the [TinySet].
+func (tinySet *TinySet[C]) Clear() {
+	clear(*tinySet)
+	*tinySet = (*tinySet)[:0]
+}
+
+// Clone returns a copy of the [TinySet].
+func (tinySet TinySet[C]) Clone() api.Set[C] { return NewTinySet(tinySet...) }
+
+// ContainsAny checks whether any of the elements is in the [TinySet].
+func (tinySet TinySet[C]) ContainsAny(elements ...C) bool { return containsAny(tinySet, elements) }
+
+// IsSubset checks whether all elements of the [TinySet] are in the other set.
+func (tinySet TinySet[C]) IsSubset(other api.ReadOnlySet[C]) bool { return isSubset(tinySet, other) }
+
+// IsSuperset checks whether all elements of the other set are in the [TinySet].
+func (tinySet TinySet[C]) IsSuperset(other api.ReadOnlySet[C]) bool {
+	return isSuperset(tinySet, other)
+}
+
+// Equal checks whether the [TinySet] and the other set have the same elements.
+func (tinySet TinySet[C]) Equal(other api.ReadOnlySet[C]) bool { return isEqual(tinySet, other) }
+
+// Disjoint checks whether the [TinySet] and the other set have no elements in common.
+func (tinySet TinySet[C]) Disjoint(other api.ReadOnlySet[C]) bool { return isDisjoint(tinySet, other) }
+
 // MarshalJSON converts the [TinySet] to a JSON array.
 func (tinySet TinySet[T]) MarshalJSON() ([]byte, error) {
 	slice := make([]T, 0, len(tinySet))
@@ -148,16 +185,13 @@ func (tinySet TinySet[T]) MarshalJSON() ([]byte, error) {
 	return json.Marshal(slice)
 }
 
-// UnmarshalJSON converts the JSON []byte to a [TinySet].
+// UnmarshalJSON converts the JSON []byte to a [TinySet], replacing its elements.
 func (tinySet *TinySet[T]) UnmarshalJSON(data []byte) error {
 	var slice []T
 	if err := json.Unmarshal(data, &slice); err != nil {
 		return err
 	}
-	if *tinySet == nil {
-		n := NewTinySet(slice...)
-		*tinySet = *n
-	}
+	*tinySet = *NewTinySet(slice...)
 	return nil
 }
 
@@ -170,18 +204,12 @@ func (tinySet TinySet[T]) MarshalYAML() (interface{}, error) {
 	return slice, nil
 }
 
-// UnmarshalYAML converts the YAML [yaml.Node] (representing a YAML list) to a [TinySet].
+// UnmarshalYAML converts the YAML [yaml.Node] (representing a YAML list) to a [TinySet], replacing its elements.
 func (tinySet *TinySet[T]) UnmarshalYAML(value *yaml.Node) error {
 	var slice []T
-	err := value.Decode(&slice)
-	if err != nil {
+	if err := value.Decode(&slice); err != nil {
 		return err
 	}
-	if *tinySet == nil {
-		n := NewTinySet[T](slice...)
-		*tinySet = *n
-	} else {
-		tinySet.Add(slice...)
-	}
+	*tinySet = *NewTinySet(slice...)
 	return nil
 }
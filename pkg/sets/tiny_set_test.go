@@ -191,3 +191,26 @@ func Test_TinySet_Encoding(t *testing.T) {
 	})
 
 }
+
+func Test_TinySet_Decode_Replaces(t *testing.T) {
+	must := require.New(t)
+	jsonSet := sets.NewTinySet("stale")
+	must.NoError(json.Unmarshal([]byte(`["a","b","a"]`), jsonSet))
+	must.Equal(sets.NewTinySet("a", "b"), jsonSet)
+
+	yamlSet := sets.NewTinySet("stale")
+	must.NoError(yaml.Unmarshal([]byte("- a\n- b\n- a\n"), yamlSet))
+	must.Equal(sets.NewTinySet("a", "b"), yamlSet)
+
+	mapSet := sets.NewSet("stale")
+	must.NoError(json.Unmarshal([]byte(`["a","b","a"]`), &mapSet))
+	must.Equal(sets.NewSet("a", "b"), mapSet)
+
+	mapSet = sets.NewSet("stale")
+	must.NoError(yaml.Unmarshal([]byte("- a\n- b\n- a\n"), &mapSet))
+	must.Equal(sets.NewSet("a", "b"), mapSet)
+
+	var nilSet sets.Set[string]
+	must.NoError(yaml.Unmarshal([]byte("- a\n"), &nilSet))
+	must.Equal(sets.NewSet("a"), nilSet)
+}
@@ -0,0 +1,309 @@
+package sliceutil
+
+import (
+	"iter"
+	"math"
+	"math/bits"
+
+	"github.com/toolvox/utilgo/pkg/errs"
+)
+
+// PowerSetSeq lazily yields the power-set of the input in 'src', in the same order as [PowerSet].
+// Every subset is a new slice.
+func PowerSetSeq[TS ~[]T, T any](src TS) iter.Seq[TS] {
+	return func(yield func(TS) bool) {
+		included := make([]bool, len(src))
+		for {
+			subset := TS{}
+			for i, in := range included {
+				if in {
+					subset = append(subset, src[i])
+				}
+			}
+			if !yield(subset) {
+				return
+			}
+			// Count up in binary, least significant element first.
+			i := 0
+			for i < len(included) && included[i] {
+				included[i] = false
+				i++
+			}
+			if i == len(included) {
+				return
+			}
+			included[i] = true
+		}
+	}
+}
+
+// ProductSeq lazily yields the Cartesian product of variable number of slices, in the same order as [Product].
+// Every combination is a new slice.
+func ProductSeq[TS ~[]T, T any](slices ...TS) iter.Seq[TS] {
+	return func(yield func(TS) bool) {
+		if len(slices) == 0 {
+			return
+		}
+		for _, slice := range slices {
+			if len(slice) == 0 {
+				return
+			}
+		}
+		indices := make([]int, len(slices))
+		for {
+			combo := make(TS, len(slices))
+			for i, index := range indices {
+				combo[i] = slices[i][index]
+			}
+			if !yield(combo) {
+				return
+			}
+			// Advance like an odometer, last slice first.
+			i := len(indices) - 1
+			for i >= 0 && indices[i] == len(slices[i])-1 {
+				indices[i] = 0
+				i--
+			}
+			if i < 0 {
+				return
+			}
+			indices[i]++
+		}
+	}
+}
+
+// Permutations lazily yields every ordering of the elements of 'src', in lexicographic order of their positions.
+// Equal elements are not merged, so a slice with duplicates yields duplicate permutations.
+// Every permutation is a new slice.
+func Permutations[TS ~[]T, T any](src TS) iter.Seq[TS] {
+	return func(yield func(TS) bool) {
+		indices := make([]int, len(src))
+		for i := range indices {
+			indices[i] = i
+		}
+		for {
+			if !yield(pick(src, indices)) {
+				return
+			}
+			// Find the last ascent, swap it with the smallest larger index after it, and reverse the tail.
+			i := len(indices) - 2
+			for i >= 0 && indices[i] > indices[i+1] {
+				i--
+			}
+			if i < 0 {
+				return
+			}
+			j := len(indices) - 1
+			for indices[j] < indices[i] {
+				j--
+			}
+			indices[i], indices[j] = indices[j], indices[i]
+			for l, r := i+1, len(indices)-1; l < r; l, r = l+1, r-1 {
+				indices[l], indices[r] = indices[r], indices[l]
+			}
+		}
+	}
+}
+
+// Combinations lazily yields every choice of k elements of 'src', keeping their order, in lexicographic order of their positions.
+// It yields nothing if k is negative or larger than len(src). Every combination is a new slice.
+func Combinations[TS ~[]T, T any](src TS, k int) iter.Seq[TS] {
+	return func(yield func(TS) bool) {
+		n := len(src)
+		if k < 0 || k > n {
+			return
+		}
+		indices := make([]int, k)
+		for i := range indices {
+			indices[i] = i
+		}
+		for {
+			if !yield(pick(src, indices)) {
+				return
+			}
+			// Advance the last index that can still move right, and reset the ones after it.
+			i := k - 1
+			for i >= 0 && indices[i] == n-k+i {
+				i--
+			}
+			if i < 0 {
+				return
+			}
+			indices[i]++
+			for j := i + 1; j < k; j++ {
+				indices[j] = indices[j-1] + 1
+			}
+		}
+	}
+}
+
+// CombinationsWithReplacement lazily yields every choice of k elements of 'src', allowing repeats and keeping their order,
+// in lexicographic order of their positions.
+// It yields nothing if k is negative, or if 'src' is empty and k is positive. Every combination is a new slice.
+func CombinationsWithReplacement[TS ~[]T, T any](src TS, k int) iter.Seq[TS] {
+	return func(yield func(TS) bool) {
+		n := len(src)
+		if k < 0 || (n == 0 && k > 0) {
+			return
+		}
+		indices := make([]int, k)
+		for {
+			if !yield(pick(src, indices)) {
+				return
+			}
+			i := k - 1
+			for i >= 0 && indices[i] == n-1 {
+				i--
+			}
+			if i < 0 {
+				return
+			}
+			indices[i]++
+			for j := i + 1; j < k; j++ {
+				indices[j] = indices[i]
+			}
+		}
+	}
+}
+
+// Factorial returns n!, the number of [Permutations] of n elements.
+// It panics if n is negative or n! does not fit in an int.
+func Factorial(n int) int {
+	if n < 0 {
+		panic(errs.Newf("factorial: negative %d", n))
+	}
+	result := 1
+	for i := 2; i <= n; i++ {
+		hi, lo := bits.Mul64(uint64(result), uint64(i))
+		if hi != 0 || lo > uint64(math.MaxInt) {
+			panic(errs.Newf("factorial: %d! overflows int", n))
+		}
+		result = int(lo)
+	}
+	return result
+}
+
+// Binomial returns n choose k, the number of [Combinations] of k out of n elements, or 0 if k is out of [0, n].
+// It panics if n is negative or the result does not fit in an int.
+func Binomial(n, k int) int {
+	if n < 0 {
+		panic(errs.Newf("binomial: negative %d", n))
+	}
+	if k < 0 || k > n {
+		return 0
+	}
+	k = min(k, n-k)
+	result := uint64(1)
+	for i := range k {
+		// C(n, i+1) = C(n, i) * (n-i) / (i+1) is exact, so only the quotient may overflow.
+		hi, lo := bits.Mul64(result, uint64(n-i))
+		if hi >= uint64(i+1) {
+			panic(errs.Newf("binomial: %d choose %d overflows int", n, k))
+		}
+		result, _ = bits.Div64(hi, lo, uint64(i+1))
+	}
+	if result > uint64(math.MaxInt) {
+		panic(errs.Newf("binomial: %d choose %d overflows int", n, k))
+	}
+	return int(result)
+}
+
+// NthPermutation returns the permutation of 'src' at the rank, in the order of [Permutations].
+// It panics if the rank is not in [0, len(src)!).
+func NthPermutation[TS ~[]T, T any](src TS, rank int) TS {
+	n := len(src)
+	if count := Factorial(n); rank < 0 || rank >= count {
+		panic(errs.Newf("nth permutation: rank %d out of %d", rank, count))
+	}
+	remaining := make([]int, n)
+	for i := range remaining {
+		remaining[i] = i
+	}
+	indices := make([]int, 0, n)
+	for i := n - 1; i >= 0; i-- {
+		// Decode the rank in the factorial number system.
+		f := Factorial(i)
+		digit := rank / f
+		rank %= f
+		indices = append(indices, remaining[digit])
+		remaining = append(remaining[:digit], remaining[digit+1:]...)
+	}
+	return pick(src, indices)
+}
+
+// PermutationRank returns the rank of the permutation of positions in the order of [Permutations], the inverse of [NthPermutation].
+// It panics if the indices are not a permutation of [0, len(indices)).
+func PermutationRank(indices []int) int {
+	n := len(indices)
+	seen := make([]bool, n)
+	var rank int
+	for i, index := range indices {
+		if index < 0 || index >= n || seen[index] {
+			panic(errs.Newf("permutation rank: %v is not a permutation", indices))
+		}
+		seen[index] = true
+		smaller := 0
+		for _, later := range indices[i+1:] {
+			if later < index {
+				smaller++
+			}
+		}
+		if smaller > 0 {
+			rank += smaller * Factorial(n-1-i)
+		}
+	}
+	return rank
+}
+
+// NthCombination returns the combination of k elements of 'src' at the rank, in the order of [Combinations].
+// It panics if the rank is not in [0, Binomial(len(src), k)).
+func NthCombination[TS ~[]T, T any](src TS, k, rank int) TS {
+	n := len(src)
+	if count := Binomial(n, k); rank < 0 || rank >= count {
+		panic(errs.Newf("nth combination: rank %d out of %d", rank, count))
+	}
+	indices := make([]int, 0, k)
+	next := 0
+	for remaining := k; remaining > 0; remaining-- {
+		// Skip every block of combinations starting with an earlier element than the one at the rank.
+		for {
+			block := Binomial(n-next-1, remaining-1)
+			if rank < block {
+				break
+			}
+			rank -= block
+			next++
+		}
+		indices = append(indices, next)
+		next++
+	}
+	return pick(src, indices)
+}
+
+// CombinationRank returns the rank of the ascending positions of a combination out of n elements in the order of [Combinations],
+// the inverse of [NthCombination].
+// It panics if the indices are not ascending positions in [0, n).
+func CombinationRank(n int, indices []int) int {
+	k := len(indices)
+	var rank int
+	next := 0
+	for i, index := range indices {
+		if index < next || index >= n {
+			panic(errs.Newf("combination rank: %v are not ascending positions of %d", indices, n))
+		}
+		for ; next < index; next++ {
+			rank += Binomial(n-next-1, k-i-1)
+		}
+		next++
+	}
+	return rank
+}
+
+// pick returns a new slice of the elements of 'src' at the indices.
+func pick[TS ~[]T, T any](src TS, indices []int) TS {
+	result := make(TS, len(indices))
+	for i, index := range indices {
+		result[i] = src[index]
+	}
+	return result
+}
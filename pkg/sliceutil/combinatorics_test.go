@@ -0,0 +1,106 @@
+package sliceutil_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/toolvox/utilgo/pkg/sliceutil"
+)
+
+func TestPowerSetSeq(t *testing.T) {
+	must := require.New(t)
+	src := []int{1, 2, 3, 4, 5}
+	for n := range len(src) + 1 {
+		must.Equal(sliceutil.PowerSet(src[:n]), slices.Collect(sliceutil.PowerSetSeq(src[:n])))
+	}
+	must.Equal([][]string{{}, {"a"}, {"b"}}, slices.Collect(sliceutil.TakeWhile(sliceutil.PowerSetSeq([]string{"a", "b", "c"}),
+		func(s []string) bool { return len(s) < 2 })))
+
+	// Lazy: the first subsets of a huge set come without allocating the rest.
+	big := make([]int, 64)
+	var count int
+	for subset := range sliceutil.PowerSetSeq(big) {
+		must.LessOrEqual(len(subset), 10)
+		if count++; count == 1_000 {
+			break
+		}
+	}
+}
+
+func TestProductSeq(t *testing.T) {
+	must := require.New(t)
+	for _, input := range [][][]int{
+		{{1, 2}},
+		{{1, 2}, {3, 4}, {5, 6, 7}},
+		{{1}, {2}, {3}},
+	} {
+		must.Equal(sliceutil.Product(input...), slices.Collect(sliceutil.ProductSeq(input...)))
+	}
+	must.Empty(slices.Collect(sliceutil.ProductSeq[[]int]()))
+	must.Empty(slices.Collect(sliceutil.ProductSeq([]int{1}, []int{})))
+}
+
+func TestPermutations(t *testing.T) {
+	must := require.New(t)
+	must.Equal([][]int{{}}, slices.Collect(sliceutil.Permutations([]int{})))
+	must.Equal([]string{"abc", "acb", "bac", "bca", "cab", "cba"},
+		slices.Collect(sliceutil.Map(sliceutil.Permutations([]rune("abc")), func(r []rune) string { return string(r) })))
+	must.Len(slices.Collect(sliceutil.Permutations([]int{1, 1, 2})), 6)
+
+	src := []int{0, 1, 2, 3, 4}
+	for rank, perm := range sliceutil.Enumerate(sliceutil.Permutations(src)) {
+		must.Equal(perm, sliceutil.NthPermutation(src, rank))
+		must.Equal(rank, sliceutil.PermutationRank(perm))
+	}
+	must.Panics(func() { sliceutil.NthPermutation(src, 120) })
+	must.Panics(func() { sliceutil.PermutationRank([]int{0, 0}) })
+	must.Equal(0, sliceutil.PermutationRank(make([]int, 0)))
+}
+
+func TestCombinations(t *testing.T) {
+	must := require.New(t)
+	toString := func(r []rune) string { return string(r) }
+	must.Equal([]string{"ab", "ac", "ad", "bc", "bd", "cd"},
+		slices.Collect(sliceutil.Map(sliceutil.Combinations([]rune("abcd"), 2), toString)))
+	must.Equal([]string{""}, slices.Collect(sliceutil.Map(sliceutil.Combinations([]rune("abc"), 0), toString)))
+	must.Empty(slices.Collect(sliceutil.Combinations([]rune("abc"), 4)))
+	must.Empty(slices.Collect(sliceutil.Combinations([]rune("abc"), -1)))
+
+	must.Equal([]string{"aa", "ab", "ac", "bb", "bc", "cc"},
+		slices.Collect(sliceutil.Map(sliceutil.CombinationsWithReplacement([]rune("abc"), 2), toString)))
+	must.Equal([]string{"aaa"}, slices.Collect(sliceutil.Map(sliceutil.CombinationsWithReplacement([]rune("a"), 3), toString)))
+	must.Empty(slices.Collect(sliceutil.CombinationsWithReplacement([]rune(""), 1)))
+
+	indices := []int{0, 1, 2, 3, 4, 5, 6}
+	for n := range len(indices) + 1 {
+		for k := range n + 1 {
+			combos := slices.Collect(sliceutil.Combinations(indices[:n], k))
+			must.Len(combos, sliceutil.Binomial(n, k))
+			for rank, combo := range combos {
+				must.Equal(combo, sliceutil.NthCombination(indices[:n], k, rank))
+				must.Equal(rank, sliceutil.CombinationRank(n, combo))
+			}
+		}
+	}
+	must.Panics(func() { sliceutil.NthCombination(indices, 3, 35) })
+	must.Panics(func() { sliceutil.CombinationRank(7, []int{2, 1}) })
+}
+
+func TestFactorial_Binomial(t *testing.T) {
+	must := require.New(t)
+	must.Equal(1, sliceutil.Factorial(0))
+	must.Equal(120, sliceutil.Factorial(5))
+	must.Equal(2432902008176640000, sliceutil.Factorial(20))
+	must.Panics(func() { sliceutil.Factorial(21) })
+	must.Panics(func() { sliceutil.Factorial(-1) })
+
+	must.Equal(1, sliceutil.Binomial(0, 0))
+	must.Equal(0, sliceutil.Binomial(3, 4))
+	must.Equal(10, sliceutil.Binomial(5, 2))
+	must.Equal(4950, sliceutil.Binomial(100, 98))
+	must.Equal(465428353255261088, sliceutil.Binomial(62, 31))
+	must.Panics(func() { sliceutil.Binomial(68, 34) })
+	must.Panics(func() { sliceutil.Binomial(-1, 0) })
+}
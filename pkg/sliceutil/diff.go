@@ -0,0 +1,321 @@
+package sliceutil
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/toolvox/utilgo/pkg/errs"
+)
+
+// EditOp is the operation of an [Edit] hunk.
+type EditOp int
+
+// Operations of [Edit] hunks.
+const (
+	// EditKeep keeps elements common to both slices.
+	EditKeep EditOp = iota
+	// EditDelete deletes elements of the old slice.
+	EditDelete
+	// EditInsert inserts elements of the new slice.
+	EditInsert
+)
+
+// String returns the name of the operation.
+func (op EditOp) String() string {
+	switch op {
+	case EditKeep:
+		return "keep"
+	case EditDelete:
+		return "delete"
+	case EditInsert:
+		return "insert"
+	}
+	return fmt.Sprintf("EditOp(%d)", int(op))
+}
+
+// Edit is a hunk of an edit script, applying the same operation to consecutive elements.
+// Kept and inserted elements are those of the new slice, deleted elements are those of the old one.
+type Edit[E any] struct {
+	Op       EditOp
+	Elements []E
+}
+
+// String returns the string representation of the hunk, like "+[a b]".
+func (e Edit[E]) String() string {
+	var prefix string
+	switch e.Op {
+	case EditKeep:
+		prefix = "="
+	case EditDelete:
+		prefix = "-"
+	case EditInsert:
+		prefix = "+"
+	}
+	return prefix + fmt.Sprint(e.Elements)
+}
+
+// Diff returns the shortest edit script turning the old slice into the new one, using Myers' algorithm.
+// Within a change, deletions come before insertions.
+func Diff[S ~[]E, E comparable](old, new S) []Edit[E] {
+	return DiffFunc(old, new, func(a, b E) bool { return a == b })
+}
+
+// DiffFunc is like [Diff], but compares elements using the eq function.
+//
+// It runs in O((N+M)D) time and O(N+M) space, where D is the number of edited elements.
+func DiffFunc[S ~[]E, E any](old, new S, eq func(a, b E) bool) []Edit[E] {
+	d := differ[E]{
+		old: old, new: new, eq: eq,
+		deleted:  make([]bool, len(old)),
+		inserted: make([]bool, len(new)),
+	}
+	d.compare(0, len(old), 0, len(new))
+	return d.script()
+}
+
+// Apply replays the edit script on the slice, returning the new slice.
+// It errors if the script does not keep or delete exactly the elements of the slice.
+func Apply[S ~[]E, E comparable](slice S, script []Edit[E]) (S, error) {
+	return ApplyFunc(slice, script, func(a, b E) bool { return a == b })
+}
+
+// ApplyFunc is like [Apply], but compares the elements of the slice to those of the script using the eq function,
+// like the script of [DiffFunc] with the same function.
+func ApplyFunc[S ~[]E, E any](slice S, script []Edit[E], eq func(a, b E) bool) (S, error) {
+	var result S
+	var pos int
+	for _, edit := range script {
+		switch edit.Op {
+		case EditKeep, EditDelete:
+			if pos+len(edit.Elements) > len(slice) {
+				return nil, errs.Newf("apply %s: %d elements past the end of %d", edit.Op, pos+len(edit.Elements)-len(slice), len(slice))
+			}
+			for i, e := range edit.Elements {
+				if !eq(slice[pos+i], e) {
+					return nil, errs.Newf("apply %s: element %d is %v, not %v", edit.Op, pos+i, slice[pos+i], e)
+				}
+			}
+			pos += len(edit.Elements)
+			if edit.Op == EditKeep {
+				result = append(result, edit.Elements...)
+			}
+		case EditInsert:
+			result = append(result, edit.Elements...)
+		default:
+			return nil, errs.Newf("apply: unknown %s", edit.Op)
+		}
+	}
+	if pos != len(slice) {
+		return nil, errs.Newf("apply: %d elements left of %d", len(slice)-pos, len(slice))
+	}
+	return result, nil
+}
+
+// UnifiedDiff renders the differences between the old and new lines as a unified diff,
+// with context unchanged lines around every change. It returns "" if there are none.
+// Lines should not end with a newline.
+func UnifiedDiff(oldName, newName string, old, new []string, context int) string {
+	type line struct {
+		op   EditOp
+		text string
+	}
+	var lines []line
+	var changes []int
+	for _, edit := range Diff(old, new) {
+		for _, text := range edit.Elements {
+			if edit.Op != EditKeep {
+				changes = append(changes, len(lines))
+			}
+			lines = append(lines, line{edit.Op, text})
+		}
+	}
+	if len(changes) == 0 {
+		return ""
+	}
+	context = max(0, context)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "--- %s\n+++ %s\n", oldName, newName)
+	var oldLine, newLine, next int
+	for i := 0; i < len(changes); {
+		start := max(next, changes[i]-context)
+		j := i
+		for j+1 < len(changes) && changes[j+1]-changes[j] <= 2*context+1 {
+			j++
+		}
+		end := min(len(lines), changes[j]+context+1)
+
+		// Lines between hunks are all kept.
+		oldLine, newLine = oldLine+start-next, newLine+start-next
+		var oldCount, newCount int
+		for _, l := range lines[start:end] {
+			if l.op != EditInsert {
+				oldCount++
+			}
+			if l.op != EditDelete {
+				newCount++
+			}
+		}
+		fmt.Fprintf(&sb, "@@ -%s +%s @@\n", unifiedRange(oldLine, oldCount), unifiedRange(newLine, newCount))
+		for _, l := range lines[start:end] {
+			switch l.op {
+			case EditKeep:
+				sb.WriteByte(' ')
+			case EditDelete:
+				sb.WriteByte('-')
+			case EditInsert:
+				sb.WriteByte('+')
+			}
+			sb.WriteString(l.text)
+			sb.WriteByte('\n')
+		}
+		oldLine, newLine, next, i = oldLine+oldCount, newLine+newCount, end, j+1
+	}
+	return sb.String()
+}
+
+// unifiedRange formats the range of count lines after the first lines, like "3,2", "3" or "2,0".
+func unifiedRange(first, count int) string {
+	switch count {
+	case 0:
+		return fmt.Sprintf("%d,0", first)
+	case 1:
+		return fmt.Sprint(first + 1)
+	}
+	return fmt.Sprintf("%d,%d", first+1, count)
+}
+
+// differ holds the state of [DiffFunc], marking the elements deleted from old and inserted from new.
+type differ[E any] struct {
+	old, new          []E
+	eq                func(a, b E) bool
+	deleted, inserted []bool
+}
+
+// compare marks the differences between old[aLo:aHi] and new[bLo:bHi], splitting them in the middle until trivial.
+func (d *differ[E]) compare(aLo, aHi, bLo, bHi int) {
+	for aLo < aHi && bLo < bHi && d.eq(d.old[aLo], d.new[bLo]) {
+		aLo, bLo = aLo+1, bLo+1
+	}
+	for aLo < aHi && bLo < bHi && d.eq(d.old[aHi-1], d.new[bHi-1]) {
+		aHi, bHi = aHi-1, bHi-1
+	}
+	switch {
+	case aLo == aHi:
+		for i := bLo; i < bHi; i++ {
+			d.inserted[i] = true
+		}
+	case bLo == bHi:
+		for i := aLo; i < aHi; i++ {
+			d.deleted[i] = true
+		}
+	default:
+		x, y, ok := d.split(aLo, aHi, bLo, bHi)
+		if !ok {
+			d.compare(aLo, aHi, bHi, bHi)
+			d.compare(aHi, aHi, bLo, bHi)
+			return
+		}
+		d.compare(aLo, x, bLo, y)
+		d.compare(x, aHi, y, bHi)
+	}
+}
+
+// split finds where the ranges can be cut in two around the middle of a shortest edit script,
+// searching forward from their start and backward from their end until the searches overlap.
+// It returns false if the ranges have nothing in common.
+func (d *differ[E]) split(aLo, aHi, bLo, bHi int) (int, int, bool) {
+	n, m := aHi-aLo, bHi-bLo
+	maxD := (n + m + 1) / 2
+	// forward and backward hold the furthest x reached on every diagonal, or -1, offset by maxD.
+	// Backward x is counted from the end.
+	forward, backward := make([]int, 2*maxD+2), make([]int, 2*maxD+2)
+	for i := range forward {
+		forward[i], backward[i] = -1, -1
+	}
+	forward[maxD+1], backward[maxD+1] = 0, 0
+
+	delta := n - m
+	odd := delta%2 != 0
+	// Diagonals that ran past the right or bottom edge are no longer searched.
+	var fStart, fEnd, bStart, bEnd int
+	for D := 0; D < maxD; D++ {
+		for k := -D + fStart; k <= D-fEnd; k += 2 {
+			i := maxD + k
+			var x int
+			if k == -D || (k != D && forward[i-1] < forward[i+1]) {
+				x = forward[i+1]
+			} else {
+				x = forward[i-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && d.eq(d.old[aLo+x], d.new[bLo+y]) {
+				x, y = x+1, y+1
+			}
+			forward[i] = x
+			switch {
+			case x > n:
+				fEnd += 2
+			case y > m:
+				fStart += 2
+			case odd:
+				if j := maxD + delta - k; j >= 0 && j < len(backward) && backward[j] != -1 && x >= n-backward[j] {
+					return aLo + x, bLo + y, true
+				}
+			}
+		}
+		for k := -D + bStart; k <= D-bEnd; k += 2 {
+			i := maxD + k
+			var x int
+			if k == -D || (k != D && backward[i-1] < backward[i+1]) {
+				x = backward[i+1]
+			} else {
+				x = backward[i-1] + 1
+			}
+			y := x - k
+			for x < n && y < m && d.eq(d.old[aHi-x-1], d.new[bHi-y-1]) {
+				x, y = x+1, y+1
+			}
+			backward[i] = x
+			switch {
+			case x > n:
+				bEnd += 2
+			case y > m:
+				bStart += 2
+			case !odd:
+				if j := maxD + delta - k; j >= 0 && j < len(forward) && forward[j] != -1 && forward[j] >= n-x {
+					fx := forward[j]
+					return aLo + fx, bLo + fx - (j - maxD), true
+				}
+			}
+		}
+	}
+	return 0, 0, false
+}
+
+// script collects the marked elements into hunks.
+func (d *differ[E]) script() []Edit[E] {
+	var script []Edit[E]
+	push := func(op EditOp, e E) {
+		if last := len(script) - 1; last >= 0 && script[last].Op == op {
+			script[last].Elements = append(script[last].Elements, e)
+			return
+		}
+		script = append(script, Edit[E]{Op: op, Elements: []E{e}})
+	}
+	i, j := 0, 0
+	for i < len(d.old) || j < len(d.new) {
+		switch {
+		case i < len(d.old) && d.deleted[i]:
+			push(EditDelete, d.old[i])
+			i++
+		case j < len(d.new) && d.inserted[j]:
+			push(EditInsert, d.new[j])
+			j++
+		default:
+			push(EditKeep, d.new[j])
+			i, j = i+1, j+1
+		}
+	}
+	return script
+}
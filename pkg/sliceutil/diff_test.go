@@ -0,0 +1,191 @@
+package sliceutil_test
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/toolvox/utilgo/pkg/sliceutil"
+)
+
+func TestDiff(t *testing.T) {
+	type edit = sliceutil.Edit[rune]
+	const (
+		keep   = sliceutil.EditKeep
+		delete = sliceutil.EditDelete
+		insert = sliceutil.EditInsert
+	)
+	cases := []struct {
+		name     string
+		old, new string
+		expected []edit
+	}{
+		{"Empty", "", "", nil},
+		{"Equal", "abc", "abc", []edit{{keep, []rune("abc")}}},
+		{"Insert all", "", "ab", []edit{{insert, []rune("ab")}}},
+		{"Delete all", "ab", "", []edit{{delete, []rune("ab")}}},
+		{"Replace", "a", "b", []edit{{delete, []rune("a")}, {insert, []rune("b")}}},
+		{"Insert middle", "ac", "abc", []edit{{keep, []rune("a")}, {insert, []rune("b")}, {keep, []rune("c")}}},
+		{"Delete ends", "xabcy", "abc", []edit{{delete, []rune("x")}, {keep, []rune("abc")}, {delete, []rune("y")}}},
+		{"Myers", "abcabba", "cbabac", []edit{
+			{delete, []rune("a")}, {insert, []rune("c")}, {keep, []rune("b")}, {delete, []rune("c")},
+			{keep, []rune("ab")}, {delete, []rune("b")}, {keep, []rune("a")}, {insert, []rune("c")},
+		}},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			must := require.New(t)
+			script := sliceutil.Diff([]rune(c.old), []rune(c.new))
+			must.Equal(c.expected, script)
+			applied, err := sliceutil.Apply([]rune(c.old), script)
+			must.NoError(err)
+			must.Equal(c.new, string(applied))
+		})
+	}
+}
+
+func TestDiff_Random(t *testing.T) {
+	must := require.New(t)
+	rng := rand.New(rand.NewPCG(3, 4))
+	random := func() []byte {
+		result := make([]byte, rng.IntN(40))
+		for i := range result {
+			result[i] = byte('a' + rng.IntN(4))
+		}
+		return result
+	}
+	for range 2_000 {
+		old, new := random(), random()
+		script := sliceutil.Diff(old, new)
+		applied, err := sliceutil.Apply(old, script)
+		must.NoError(err)
+		must.Equal(string(new), string(applied))
+
+		var edits int
+		for i, edit := range script {
+			must.NotEmpty(edit.Elements)
+			if i > 0 {
+				must.NotEqual(script[i-1].Op, edit.Op)
+			}
+			if edit.Op != sliceutil.EditKeep {
+				edits += len(edit.Elements)
+			}
+		}
+		must.Equal(len(old)+len(new)-2*lcs(old, new), edits, "%s -> %s: %v", old, new, script)
+	}
+}
+
+func TestDiffFunc(t *testing.T) {
+	must := require.New(t)
+	script := sliceutil.DiffFunc([]string{"A", "b", "C"}, []string{"a", "B", "d"}, strings.EqualFold)
+	must.Equal([]sliceutil.Edit[string]{
+		{Op: sliceutil.EditKeep, Elements: []string{"a", "B"}},
+		{Op: sliceutil.EditDelete, Elements: []string{"C"}},
+		{Op: sliceutil.EditInsert, Elements: []string{"d"}},
+	}, script)
+	must.Equal("[=[a B] -[C] +[d]]", fmt.Sprint(script))
+
+	applied, err := sliceutil.ApplyFunc([]string{"A", "b", "C"}, script, strings.EqualFold)
+	must.NoError(err)
+	must.Equal([]string{"a", "B", "d"}, applied)
+	_, err = sliceutil.Apply([]string{"A", "b", "C"}, script)
+	must.ErrorContains(err, "element 0 is A, not a")
+}
+
+func TestApply_Errors(t *testing.T) {
+	must := require.New(t)
+	_, err := sliceutil.Apply([]int{1, 2}, []sliceutil.Edit[int]{{Op: sliceutil.EditKeep, Elements: []int{1, 2, 3}}})
+	must.ErrorContains(err, "past the end")
+	_, err = sliceutil.Apply([]int{1, 2}, []sliceutil.Edit[int]{{Op: sliceutil.EditDelete, Elements: []int{1}}})
+	must.ErrorContains(err, "1 elements left of 2")
+	_, err = sliceutil.Apply([]int{1}, []sliceutil.Edit[int]{{Op: sliceutil.EditOp(7), Elements: []int{1}}})
+	must.ErrorContains(err, "EditOp(7)")
+	_, err = sliceutil.Apply([]int{1, 2}, []sliceutil.Edit[int]{{Op: sliceutil.EditKeep, Elements: []int{1}}, {Op: sliceutil.EditDelete, Elements: []int{3}}})
+	must.ErrorContains(err, "apply delete: element 1 is 2, not 3")
+}
+
+func TestUnifiedDiff(t *testing.T) {
+	must := require.New(t)
+	old := strings.Split("a b c d e f g h i j k", " ")
+	new := strings.Split("a B c d e f g h i k l", " ")
+
+	must.Empty(sliceutil.UnifiedDiff("old", "new", old, old, 3))
+	must.Equal(`--- old
++++ new
+@@ -1,3 +1,3 @@
+ a
+-b
++B
+ c
+@@ -9,3 +9,3 @@
+ i
+-j
+ k
++l
+`, sliceutil.UnifiedDiff("old", "new", old, new, 1))
+	must.Equal(`--- old
++++ new
+@@ -1,5 +1,5 @@
+ a
+-b
++B
+ c
+ d
+ e
+@@ -7,5 +7,5 @@
+ g
+ h
+ i
+-j
+ k
++l
+`, sliceutil.UnifiedDiff("old", "new", old, new, 3))
+	must.Equal(`--- old
++++ new
+@@ -1,11 +1,11 @@
+ a
+-b
++B
+ c
+ d
+ e
+ f
+ g
+ h
+ i
+-j
+ k
++l
+`, sliceutil.UnifiedDiff("old", "new", old, new, 4))
+	must.Equal(`--- a
++++ b
+@@ -0,0 +1,2 @@
++x
++y
+`, sliceutil.UnifiedDiff("a", "b", nil, []string{"x", "y"}, 3))
+	must.Equal(`--- a
++++ b
+@@ -2 +1,0 @@
+-y
+`, sliceutil.UnifiedDiff("a", "b", []string{"x", "y"}, []string{"x"}, 0))
+}
+
+// lcs is the length of the longest common subsequence, by dynamic programming.
+func lcs(a, b []byte) int {
+	prev, curr := make([]int, len(b)+1), make([]int, len(b)+1)
+	for i := range a {
+		for j := range b {
+			if a[i] == b[j] {
+				curr[j+1] = prev[j] + 1
+			} else {
+				curr[j+1] = max(prev[j+1], curr[j])
+			}
+		}
+		prev, curr = curr, prev
+	}
+	return prev[len(b)]
+}
@@ -0,0 +1,220 @@
+package sliceutil
+
+import (
+	"iter"
+	"slices"
+
+	"github.com/toolvox/utilgo/api"
+	"github.com/toolvox/utilgo/pkg/errs"
+)
+
+// FromSet iterates lazily over the elements of any set, without copying them if the set supports iteration.
+func FromSet[C comparable](set api.ReadOnlySet[C]) iter.Seq[C] {
+	if iterable, ok := set.(interface{ All() iter.Seq[C] }); ok {
+		return iterable.All()
+	}
+	return slices.Values(set.Elements())
+}
+
+// Map transforms each element of the sequence using the selector function.
+func Map[T1, T2 any](seq iter.Seq[T1], selector func(T1) T2) iter.Seq[T2] {
+	return func(yield func(T2) bool) {
+		for element := range seq {
+			if !yield(selector(element)) {
+				return
+			}
+		}
+	}
+}
+
+// Map2 transforms each pair of the sequence into a single element using the selector function.
+func Map2[K, V, T any](seq iter.Seq2[K, V], selector func(K, V) T) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for k, v := range seq {
+			if !yield(selector(k, v)) {
+				return
+			}
+		}
+	}
+}
+
+// Filter yields the elements of the sequence for which pred returns true.
+func Filter[T any](seq iter.Seq[T], pred func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for element := range seq {
+			if pred(element) && !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// Filter2 yields the pairs of the sequence for which pred returns true.
+func Filter2[K, V any](seq iter.Seq2[K, V], pred func(K, V) bool) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		for k, v := range seq {
+			if pred(k, v) && !yield(k, v) {
+				return
+			}
+		}
+	}
+}
+
+// FlatMap transforms each element of the sequence into a sequence, and yields the elements of all of them in order.
+func FlatMap[T1, T2 any](seq iter.Seq[T1], selector func(T1) iter.Seq[T2]) iter.Seq[T2] {
+	return func(yield func(T2) bool) {
+		for element := range seq {
+			for inner := range selector(element) {
+				if !yield(inner) {
+					return
+				}
+			}
+		}
+	}
+}
+
+// Zip pairs the elements of two sequences in order, stopping when the shorter one ends.
+func Zip[T1, T2 any](seq1 iter.Seq[T1], seq2 iter.Seq[T2]) iter.Seq2[T1, T2] {
+	return func(yield func(T1, T2) bool) {
+		next, stop := iter.Pull(seq2)
+		defer stop()
+		for e1 := range seq1 {
+			e2, ok := next()
+			if !ok || !yield(e1, e2) {
+				return
+			}
+		}
+	}
+}
+
+// Enumerate pairs the elements of the sequence with their index.
+func Enumerate[T any](seq iter.Seq[T]) iter.Seq2[int, T] {
+	return func(yield func(int, T) bool) {
+		i := 0
+		for element := range seq {
+			if !yield(i, element) {
+				return
+			}
+			i++
+		}
+	}
+}
+
+// Chunk splits the sequence into consecutive slices of size elements; the last one may be shorter.
+// Every chunk is a new slice. Chunk panics if size is less than 1.
+func Chunk[T any](seq iter.Seq[T], size int) iter.Seq[[]T] {
+	if size < 1 {
+		panic(errs.Newf("chunk: size %d is less than 1", size))
+	}
+	return func(yield func([]T) bool) {
+		chunk := make([]T, 0, size)
+		for element := range seq {
+			chunk = append(chunk, element)
+			if len(chunk) == size {
+				if !yield(chunk) {
+					return
+				}
+				chunk = make([]T, 0, size)
+			}
+		}
+		if len(chunk) > 0 {
+			yield(chunk)
+		}
+	}
+}
+
+// Window yields every run of size consecutive elements of the sequence, sliding by one element.
+// A sequence shorter than size yields nothing. Every window is a new slice. Window panics if size is less than 1.
+func Window[T any](seq iter.Seq[T], size int) iter.Seq[[]T] {
+	if size < 1 {
+		panic(errs.Newf("window: size %d is less than 1", size))
+	}
+	return func(yield func([]T) bool) {
+		window := make([]T, 0, size)
+		for element := range seq {
+			if len(window) == size {
+				window = append(make([]T, 0, size), window[1:]...)
+			}
+			window = append(window, element)
+			if len(window) == size && !yield(window) {
+				return
+			}
+		}
+	}
+}
+
+// Scan yields the running accumulation of the sequence, starting from initial, after each element.
+func Scan[T, A any](seq iter.Seq[T], initial A, accumulate func(A, T) A) iter.Seq[A] {
+	return func(yield func(A) bool) {
+		acc := initial
+		for element := range seq {
+			acc = accumulate(acc, element)
+			if !yield(acc) {
+				return
+			}
+		}
+	}
+}
+
+// Reduce accumulates all elements of the sequence, starting from initial, and returns the result.
+func Reduce[T, A any](seq iter.Seq[T], initial A, accumulate func(A, T) A) A {
+	acc := initial
+	for element := range seq {
+		acc = accumulate(acc, element)
+	}
+	return acc
+}
+
+// TakeWhile yields the elements of the sequence until pred first returns false.
+func TakeWhile[T any](seq iter.Seq[T], pred func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for element := range seq {
+			if !pred(element) || !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// DropWhile skips the elements of the sequence until pred first returns false, and yields the rest.
+func DropWhile[T any](seq iter.Seq[T], pred func(T) bool) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		dropping := true
+		for element := range seq {
+			if dropping && pred(element) {
+				continue
+			}
+			dropping = false
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// Distinct yields the first occurrence of every element of the sequence.
+// It remembers every element seen, so memory grows with the number of distinct elements.
+func Distinct[T comparable](seq iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		seen := map[T]struct{}{}
+		for element := range seq {
+			if _, ok := seen[element]; ok {
+				continue
+			}
+			seen[element] = struct{}{}
+			if !yield(element) {
+				return
+			}
+		}
+	}
+}
+
+// GroupBy collects the elements of the sequence by their key, keeping their order within each group.
+func GroupBy[T any, K comparable](seq iter.Seq[T], key func(T) K) map[K][]T {
+	groups := map[K][]T{}
+	for element := range seq {
+		k := key(element)
+		groups[k] = append(groups[k], element)
+	}
+	return groups
+}
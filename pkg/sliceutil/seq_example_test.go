@@ -0,0 +1,33 @@
+package sliceutil_test
+
+import (
+	"fmt"
+	"path"
+	"testing/fstest"
+
+	"github.com/toolvox/utilgo/pkg/fsutil"
+	"github.com/toolvox/utilgo/pkg/sliceutil"
+)
+
+func ExampleGroupBy() {
+	fsys := fstest.MapFS{
+		"docs/a.md":     {Data: []byte("# A")},
+		"docs/b.md":     {Data: []byte("# B")},
+		"src/main.go":   {Data: []byte("package main")},
+		"src/util/x.go": {Data: []byte("package util")},
+	}
+
+	paths := sliceutil.Map2(fsutil.IterFS(fsys, ".", "*/*"), func(path string, err error) string {
+		if err != nil {
+			panic(err)
+		}
+		return path
+	})
+	byExt := sliceutil.GroupBy(paths, path.Ext)
+	fmt.Println(byExt[".md"])
+	fmt.Println(byExt[".go"])
+
+	// Output:
+	// [docs/a.md docs/b.md]
+	// [src/main.go]
+}
@@ -0,0 +1,108 @@
+package sliceutil_test
+
+import (
+	"iter"
+	"maps"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/toolvox/utilgo/pkg/sets"
+	"github.com/toolvox/utilgo/pkg/sliceutil"
+)
+
+func isEven(i int) bool { return i%2 == 0 }
+
+func Test_Seq(t *testing.T) {
+	numbers := slices.Values([]int{1, 2, 3, 4, 5})
+
+	t.Run("Adapters", func(t *testing.T) {
+		must := require.New(t)
+		must.Equal([]int{1, 2, 3, 4, 5}, slices.Collect(numbers))
+		must.Equal([]int{1, 2, 3}, slices.Collect(sliceutil.FromSet[int](sets.NewOrderedSet(1, 2, 3))))
+		must.ElementsMatch([]int{1, 2, 3}, slices.Collect(sliceutil.FromSet[int](sets.NewSet(1, 2, 3))))
+	})
+
+	t.Run("Map|Filter", func(t *testing.T) {
+		must := require.New(t)
+		must.Equal([]int{2, 4, 6, 8, 10}, slices.Collect(sliceutil.Map(numbers, func(i int) int { return i * 2 })))
+		must.Equal([]int{2, 4}, slices.Collect(sliceutil.Filter(numbers, isEven)))
+		must.Equal([]string{"1a", "2b"}, slices.Collect(sliceutil.Map2(sliceutil.Enumerate(slices.Values([]string{"a", "b"})),
+			func(i int, s string) string { return string(rune('1'+i)) + s })))
+		must.Equal(map[int]int{2: 2, 4: 4}, maps.Collect(sliceutil.Filter2(maps.All(map[int]int{1: 1, 2: 2, 3: 3, 4: 4}),
+			func(k, _ int) bool { return isEven(k) })))
+		must.Equal([]string{"a", "b", "c", "d"}, slices.Collect(sliceutil.FlatMap(slices.Values([]string{"a b", "", "c d"}),
+			func(s string) iter.Seq[string] { return slices.Values(strings.Fields(s)) })))
+	})
+
+	t.Run("Zip|Enumerate", func(t *testing.T) {
+		must := require.New(t)
+		var keys []int
+		var values []string
+		for k, v := range sliceutil.Zip(numbers, slices.Values([]string{"a", "b", "c"})) {
+			keys, values = append(keys, k), append(values, v)
+		}
+		must.Equal([]int{1, 2, 3}, keys)
+		must.Equal([]string{"a", "b", "c"}, values)
+
+		indices := map[int]int{}
+		for i, n := range sliceutil.Enumerate(numbers) {
+			indices[i] = n
+			if i == 2 {
+				break
+			}
+		}
+		must.Equal(map[int]int{0: 1, 1: 2, 2: 3}, indices)
+	})
+
+	t.Run("Chunk|Window", func(t *testing.T) {
+		must := require.New(t)
+		must.Equal([][]int{{1, 2}, {3, 4}, {5}}, slices.Collect(sliceutil.Chunk(numbers, 2)))
+		must.Equal([][]int{{1, 2, 3, 4, 5}}, slices.Collect(sliceutil.Chunk(numbers, 10)))
+		must.Equal([][]int{{1, 2, 3}, {2, 3, 4}, {3, 4, 5}}, slices.Collect(sliceutil.Window(numbers, 3)))
+		must.Empty(slices.Collect(sliceutil.Window(numbers, 6)))
+		must.Panics(func() { sliceutil.Chunk(numbers, 0) })
+		must.Panics(func() { sliceutil.Window(numbers, 0) })
+	})
+
+	t.Run("Scan|Reduce", func(t *testing.T) {
+		must := require.New(t)
+		sum := func(acc, i int) int { return acc + i }
+		must.Equal([]int{1, 3, 6, 10, 15}, slices.Collect(sliceutil.Scan(numbers, 0, sum)))
+		must.Equal(15, sliceutil.Reduce(numbers, 0, sum))
+		must.Equal("12345", sliceutil.Reduce(numbers, "", func(acc string, i int) string { return acc + string(rune('0'+i)) }))
+	})
+
+	t.Run("TakeWhile|DropWhile", func(t *testing.T) {
+		must := require.New(t)
+		small := func(i int) bool { return i < 3 }
+		must.Equal([]int{1, 2}, slices.Collect(sliceutil.TakeWhile(numbers, small)))
+		must.Equal([]int{3, 4, 5}, slices.Collect(sliceutil.DropWhile(numbers, small)))
+		must.Equal([]int{2, 1}, slices.Collect(sliceutil.DropWhile(slices.Values([]int{1, 2, 1}), func(i int) bool { return i == 1 })))
+	})
+
+	t.Run("Distinct|GroupBy", func(t *testing.T) {
+		must := require.New(t)
+		must.Equal([]int{3, 1, 2}, slices.Collect(sliceutil.Distinct(slices.Values([]int{3, 1, 3, 2, 1}))))
+		must.Equal(map[bool][]int{true: {2, 4}, false: {1, 3, 5}}, sliceutil.GroupBy(numbers, isEven))
+	})
+
+	t.Run("Lazy", func(t *testing.T) {
+		must := require.New(t)
+		var pulled int
+		naturals := func(yield func(int) bool) {
+			for i := 0; ; i++ {
+				pulled++
+				if !yield(i) {
+					return
+				}
+			}
+		}
+		evens := sliceutil.Filter(naturals, isEven)
+		squares := sliceutil.Map(evens, func(i int) int { return i * i })
+		must.Equal([]int{0, 4, 16}, slices.Collect(sliceutil.TakeWhile(squares, func(i int) bool { return i < 20 })))
+		must.Equal(7, pulled)
+	})
+}
@@ -3,7 +3,8 @@ package tmplz
 import (
 	"fmt"
 	"maps"
-	"reflect"
+
+	"github.com/toolvox/utilgo/pkg/reflectutil"
 )
 
 // Assignments holds key-value pairs for template variable assignments.
@@ -29,10 +30,14 @@ func (a Assignments) AssignMap(kvps map[string]any) {
 	}
 }
 
-// AssignObj uses the exported fields of the provided object to assign key-value pairs to the Assignments.
-// Each field name is used as a key.
+// AssignObj uses the exported fields of the provided struct, or pointer to one, to assign key-value pairs to the Assignments.
+// Each field name is used as a key, unless renamed by a `tmplz:"name"` tag.
+// Other values, and structs which contain themselves, assign nothing.
 func (a Assignments) AssignObj(obj any) {
-	dict := utilStructToMap(obj)
+	dict, err := reflectutil.ToMap(obj, reflectutil.ConvertOptions{Tags: []string{"tmplz"}})
+	if err != nil {
+		return
+	}
 	a.AssignMap(dict)
 }
 
@@ -40,20 +45,3 @@ func (a Assignments) AssignObj(obj any) {
 func (a Assignments) Clone() Assignments {
 	return maps.Clone(a)
 }
-
-// utilStructToMap is a helper function that converts the exported fields of a struct to a map[string]any.
-// Each field name becomes a key in the map.
-func utilStructToMap(obj any) map[string]any {
-	// TODO: this function wants to be in maputil
-	result := make(map[string]any)
-	objValue := reflect.ValueOf(obj)
-
-	if objValue.Kind() == reflect.Struct {
-		objType := objValue.Type()
-		for i := 0; i < objValue.NumField(); i++ {
-			field := objValue.Field(i)
-			result[objType.Field(i).Name] = field.Interface()
-		}
-	}
-	return result
-}
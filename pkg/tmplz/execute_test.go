@@ -614,3 +614,20 @@ func Test_Execute_Templates(t *testing.T) {
 		}
 	})
 }
+
+func Test_AssignObj(t *testing.T) {
+	type node struct {
+		Name   string `tmplz:"name"`
+		Parent *node
+	}
+	must := require.New(t)
+	assignments := Assignments{}
+	assignments.AssignObj(&node{Name: "leaf"})
+	must.Equal(Assignments{"name": "leaf", "Parent": nil}, assignments)
+
+	self := &node{Name: "self"}
+	self.Parent = self
+	assignments = Assignments{}
+	assignments.AssignObj(self)
+	must.Empty(assignments)
+}
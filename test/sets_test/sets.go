@@ -8,10 +8,11 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/toolvox/utilgo/api"
+	"github.com/toolvox/utilgo/pkg/sets"
 	"github.com/toolvox/utilgo/test"
 )
 
-func Run_Test_Set[S api.BasicSet[C], C comparable](t *testing.T, ctor test.TestConstructorFor[S], data test.TypedTestData[C], maxN int) {
+func Run_Test_Set[S api.Set[C], C comparable](t *testing.T, ctor test.TestConstructorFor[S], data test.TypedTestData[C], maxN int) {
 	t.Run("BasicSet", func(t *testing.T) {
 		t.Run("Len|Add", func(t *testing.T) {
 			must := require.New(t)
@@ -100,4 +101,95 @@ func Run_Test_Set[S api.BasicSet[C], C comparable](t *testing.T, ctor test.TestC
 			}
 		})
 	})
+	t.Run("Set", func(t *testing.T) {
+		elements := func(from, to int) []C {
+			result := make([]C, 0, to-from)
+			for i := from; i < to; i++ {
+				result = append(result, data.Element(i))
+			}
+			return result
+		}
+		newSet := func(from, to int) S {
+			testSet := ctor.New()
+			testSet.Add(elements(from, to)...)
+			return testSet
+		}
+
+		for n := 1; n <= maxN; n *= 10 {
+			t.Run(fmt.Sprint("n=", n), func(t *testing.T) {
+				t.Run("All", func(t *testing.T) {
+					must := require.New(t)
+					testSet := newSet(0, n)
+					var all []C
+					for e := range testSet.All() {
+						all = append(all, e)
+					}
+					must.Len(all, n)
+					must.True(sets.NewSet(all...).Equal(testSet))
+					var count int
+					for range testSet.All() {
+						count++
+						break
+					}
+					must.Equal(1, count)
+				})
+				t.Run("Clear|Clone", func(t *testing.T) {
+					must := require.New(t)
+					testSet := newSet(0, n)
+					clone := testSet.Clone()
+					must.IsType(testSet, clone)
+					must.True(clone.Equal(testSet))
+					testSet.Clear()
+					must.Equal(0, testSet.Len())
+					must.False(testSet.ContainsAny(elements(0, n)...))
+					must.Equal(n, clone.Len())
+					must.True(clone.Contains(elements(0, n)...))
+					testSet.Add(data.Element(n))
+					must.Equal(1, testSet.Len())
+					must.False(clone.Contains(data.Element(n)))
+				})
+				t.Run("Predicates", func(t *testing.T) {
+					must := require.New(t)
+					low, all, high := newSet(0, n), newSet(0, 2*n), newSet(n, 2*n)
+					mapSet := sets.NewSet(elements(0, n)...)
+
+					must.True(low.ContainsAny(data.Element(2*n), data.Element(0)))
+					must.False(low.ContainsAny(data.Element(2 * n)))
+					must.False(low.ContainsAny())
+
+					must.True(low.IsSubset(all))
+					must.True(low.IsSubset(mapSet))
+					must.False(all.IsSubset(low))
+					must.True(all.IsSuperset(high))
+					must.True(low.IsSuperset(mapSet))
+					must.False(low.IsSuperset(all))
+
+					must.True(low.Equal(mapSet))
+					must.True(mapSet.Equal(low))
+					must.False(low.Equal(all))
+					must.False(low.Equal(high))
+
+					must.True(low.Disjoint(high))
+					must.True(high.Disjoint(mapSet))
+					must.False(low.Disjoint(all))
+					must.True(ctor.New().Disjoint(all))
+				})
+				t.Run("Algebra", func(t *testing.T) {
+					must := require.New(t)
+					low, high := newSet(0, n), sets.NewSet(elements(n/2, n+n/2)...)
+
+					must.True(sets.NewSet(elements(0, n+n/2)...).Equal(sets.Union[C](low, high)))
+					must.True(sets.NewSet(elements(n/2, n)...).Equal(sets.Intersection[C](low, high)))
+					must.True(sets.NewSet(elements(0, n/2)...).Equal(sets.Difference[C](low, high)))
+					must.True(sets.NewSet(append(elements(0, n/2), elements(n, n+n/2)...)...).Equal(sets.SymmetricDifference[C](low, high)))
+
+					blr := sets.ThreeWay[C](low, high)
+					must.True(sets.NewSet(elements(n/2, n)...).Equal(blr[0]))
+					must.True(sets.NewSet(elements(0, n/2)...).Equal(blr[1]))
+					must.True(sets.NewSet(elements(n, n+n/2)...).Equal(blr[2]))
+					must.Equal(n, low.Len())
+				})
+			})
+		}
+	})
 }